@@ -1,17 +1,26 @@
 package test
 
 import (
+	"archive/tar"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/suite"
+	podmanFilters "go.podman.io/common/pkg/filters"
 
 	"github.com/manusa/podman-mcp-server/pkg/config"
 	mcpServer "github.com/manusa/podman-mcp-server/pkg/mcp"
@@ -48,6 +57,8 @@ type McpSuite struct {
 	mcpHttpServer *httptest.Server
 	mcpClient     *mcp.Client
 	mcpSession    *mcp.ClientSession
+	progressMu    sync.Mutex
+	progress      []string
 }
 
 // SetupTest initializes the mock server, MCP server, and client before each test.
@@ -87,7 +98,14 @@ func (s *McpSuite) SetupTest() {
 	s.mcpHttpServer = httptest.NewServer(streamableHandler)
 
 	// Create MCP client and connect
-	s.mcpClient = mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1.33.7"}, nil)
+	s.progress = nil
+	s.mcpClient = mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1.33.7"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			s.progressMu.Lock()
+			defer s.progressMu.Unlock()
+			s.progress = append(s.progress, req.Params.Message)
+		},
+	})
 	transport := &mcp.StreamableClientTransport{Endpoint: s.mcpHttpServer.URL}
 	s.mcpSession, err = s.mcpClient.Connect(s.T().Context(), transport, nil)
 	s.Require().NoError(err)
@@ -109,12 +127,35 @@ func (s *McpSuite) TearDownTest() {
 
 // CallTool calls an MCP tool by name with the given arguments.
 func (s *McpSuite) CallTool(name string, args map[string]interface{}) (*mcp.CallToolResult, error) {
-	return s.mcpSession.CallTool(s.T().Context(), &mcp.CallToolParams{
+	return s.CallToolWithContext(s.T().Context(), name, args)
+}
+
+// CallToolWithContext calls an MCP tool by name with the given arguments, the call is cancelled when ctx is done.
+func (s *McpSuite) CallToolWithContext(ctx context.Context, name string, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	return s.mcpSession.CallTool(ctx, &mcp.CallToolParams{
 		Name:      name,
 		Arguments: args,
 	})
 }
 
+// CallToolWithProgress calls an MCP tool by name with the given arguments including a progress token
+// so that the server sends progress notifications for the call (see ProgressMessages).
+func (s *McpSuite) CallToolWithProgress(name string, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	params := &mcp.CallToolParams{
+		Name:      name,
+		Arguments: args,
+	}
+	params.SetProgressToken(name)
+	return s.mcpSession.CallTool(s.T().Context(), params)
+}
+
+// ProgressMessages returns the messages of the progress notifications received by the client.
+func (s *McpSuite) ProgressMessages() []string {
+	s.progressMu.Lock()
+	defer s.progressMu.Unlock()
+	return slices.Clone(s.progress)
+}
+
 // CallToolRawResponse represents the raw JSON-RPC response from the server.
 // It handles both success and error responses since JSON-RPC uses mutually exclusive
 // result/error fields. The library provides separate JSONRPCResponse (success only)
@@ -210,9 +251,26 @@ func (s *McpSuite) ListTools() (*mcp.ListToolsResult, error) {
 }
 
 // WithContainerList sets up the mock server to return a list of containers.
+// Like podman, the containers are filtered by the label filters of the request: a container must have all the labels
+// of the label filter, and is excluded if it has all the labels of the label! filter.
 func (s *McpSuite) WithContainerList(containers []ContainerListResponse) {
-	handler := func(w http.ResponseWriter, _ *http.Request) {
-		WriteJSON(w, containers)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var filters map[string][]string
+		if queryFilters := r.URL.Query().Get("filters"); queryFilters != "" {
+			_ = json.Unmarshal([]byte(queryFilters), &filters)
+		}
+		if len(filters["label"]) == 0 && len(filters["label!"]) == 0 {
+			WriteJSON(w, containers)
+			return
+		}
+		matching := make([]ContainerListResponse, 0, len(containers))
+		for _, container := range containers {
+			if podmanFilters.MatchLabelFilters(filters["label"], container.Labels) &&
+				(len(filters["label!"]) == 0 || !podmanFilters.MatchLabelFilters(filters["label!"], container.Labels)) {
+				matching = append(matching, container)
+			}
+		}
+		WriteJSON(w, matching)
 	}
 	s.MockServer.HandleFunc("GET", "/libpod/containers/json", "/containers/json", handler)
 }
@@ -233,6 +291,62 @@ func (s *McpSuite) WithImageList(images []ImageListResponse) {
 	s.MockServer.HandleFunc("GET", "/libpod/images/json", "/images/json", handler)
 }
 
+// WithImageInspect sets up the mock server to return image inspect data.
+func (s *McpSuite) WithImageInspect(image ImageInspectResponse) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, image)
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/images/{name}/json", "/images/{name}/json", handler)
+}
+
+// WithImageHistory sets up the mock server to return the layer history of an image.
+func (s *McpSuite) WithImageHistory(layers []ImageHistoryResponse) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, layers)
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/images/{name}/history", "/images/{name}/history", handler)
+}
+
+// WithImageTag sets up the mock server to handle image tag.
+func (s *McpSuite) WithImageTag() {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/images/{name}/tag", "/images/{name}/tag", handler)
+}
+
+// WithImageUntag sets up the mock server to handle image untag (Libpod API only).
+func (s *McpSuite) WithImageUntag() {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}
+	s.MockServer.Handle("POST", "/libpod/images/{name}/untag", handler)
+}
+
+// WithImageExport sets up the mock server to export images (Libpod API only) as a tar archive of the files (path to content).
+// The archive is written as is for the archive formats and extracted for the directory formats.
+func (s *McpSuite) WithImageExport(files map[string]string) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/x-tar")
+		tw := tar.NewWriter(w)
+		for _, name := range slices.Sorted(maps.Keys(files)) {
+			_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(files[name]))})
+			_, _ = tw.Write([]byte(files[name]))
+		}
+		_ = tw.Close()
+	}
+	s.MockServer.Handle("GET", "/libpod/images/export", handler)
+}
+
+// WithImageLoad sets up the mock server to load image archives (Libpod API only) reporting the loaded image names.
+// The uploaded archive can be asserted from the captured request body.
+func (s *McpSuite) WithImageLoad(names ...string) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, map[string][]string{"Names": names})
+	}
+	s.MockServer.Handle("POST", "/libpod/images/load", handler)
+}
+
 // WithNetworkList sets up the mock server to return a list of networks.
 func (s *McpSuite) WithNetworkList(networks []NetworkListResponse) {
 	handler := func(w http.ResponseWriter, _ *http.Request) {
@@ -271,21 +385,203 @@ func (s *McpSuite) WithError(method, libpodPath, dockerPath string, statusCode i
 func (s *McpSuite) WithContainerLogs(logs string) {
 	handler := func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/octet-stream")
-		// Docker logs use multiplexed stream format for non-TTY containers
-		// Format: [STREAM_TYPE][0][0][0][SIZE_BE_32][DATA...]
-		// STREAM_TYPE: 0=stdin, 1=stdout, 2=stderr
-		frame := make([]byte, 8+len(logs))
-		frame[0] = 1 // stdout
-		frame[4] = byte(len(logs) >> 24)
-		frame[5] = byte(len(logs) >> 16)
-		frame[6] = byte(len(logs) >> 8)
-		frame[7] = byte(len(logs))
-		copy(frame[8:], logs)
-		_, _ = w.Write(frame)
+		WriteMultiplexedFrame(w, 1, logs)
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/containers/{id}/logs", "/containers/{id}/logs", handler)
+}
+
+// WithContainerOutput sets up the mock server to return container logs with separate stdout and stderr frames.
+func (s *McpSuite) WithContainerOutput(stdout, stderr string) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		if stdout != "" {
+			WriteMultiplexedFrame(w, 1, stdout)
+		}
+		if stderr != "" {
+			WriteMultiplexedFrame(w, 2, stderr)
+		}
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/containers/{id}/logs", "/containers/{id}/logs", handler)
+}
+
+// WithContainerLogsFollow sets up the mock server to stream container logs that are being followed.
+// Each line is written as a separate frame and the connection is kept open until the client closes it.
+func (s *McpSuite) WithContainerLogsFollow(lines ...string) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		for _, line := range lines {
+			WriteMultiplexedFrame(w, 1, line+"\n")
+			w.(http.Flusher).Flush()
+		}
+		<-r.Context().Done()
 	}
 	s.MockServer.HandleFunc("GET", "/libpod/containers/{id}/logs", "/containers/{id}/logs", handler)
 }
 
+// WithContainerStats sets up the mock server to return container stats.
+// Each request returns the next sample, the last sample is repeated once all have been returned.
+func (s *McpSuite) WithContainerStats(samples ...[]ContainerStatsResponse) {
+	var mu sync.Mutex
+	requests := 0
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		sample := samples[min(requests, len(samples)-1)]
+		requests++
+		mu.Unlock()
+		WriteJSON(w, ContainerStatsReport{Stats: sample})
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/containers/stats", "/containers/stats", handler)
+}
+
+// WithContainerTop sets up the mock server to return the processes running in a container.
+func (s *McpSuite) WithContainerTop(top ContainerTopResponse) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, top)
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/containers/{id}/top", "/containers/{id}/top", handler)
+}
+
+// WithContainerDiff sets up the mock server to return the filesystem changes of a container.
+func (s *McpSuite) WithContainerDiff(changes []ContainerChange) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, changes)
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/containers/{id}/changes", "/containers/{id}/changes", handler)
+}
+
+// WithContainerHealthcheck sets up the mock server to run the container healthcheck, resulting in the specified status.
+func (s *McpSuite) WithContainerHealthcheck(status string) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, HealthState{Status: status})
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/containers/{id}/healthcheck", "", handler)
+}
+
+// WithContainerCommit sets up the mock server to handle container commits, creating the image with the specified ID.
+func (s *McpSuite) WithContainerCommit(imageID string) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		WriteJSON(w, map[string]string{"Id": imageID})
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/commit", "/commit", handler)
+}
+
+// WithContainerArchive sets up the mock server to serve the container files (path to content) through the archive endpoints.
+// Directories are inferred from the file paths, the files copied to the container can be asserted from the captured PUT request tar body.
+func (s *McpSuite) WithContainerArchive(files map[string]string) {
+	modTime := time.Unix(1704067200, 0)
+	stat := func(w http.ResponseWriter, r *http.Request) (string, bool) {
+		p := path.Clean(r.URL.Query().Get("path"))
+		info := ContainerPathStat{Name: path.Base(p), Mode: 0o644, ModTime: modTime, LinkTarget: p}
+		if content, ok := files[p]; ok {
+			info.Size = int64(len(content))
+		} else if p == "/" || slices.ContainsFunc(slices.Collect(maps.Keys(files)), func(f string) bool { return strings.HasPrefix(f, p+"/") }) {
+			info.IsDir = true
+			info.Mode = os.ModeDir | 0o755
+		} else {
+			WriteError(w, http.StatusNotFound, "no such file or directory")
+			return "", false
+		}
+		raw, _ := json.Marshal(info)
+		w.Header().Set("X-Docker-Container-Path-Stat", base64.URLEncoding.EncodeToString(raw))
+		return p, true
+	}
+	statHandler := func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := stat(w, r); ok {
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+	s.MockServer.HandleFunc("HEAD", "/libpod/containers/{id}/archive", "/containers/{id}/archive", statHandler)
+
+	getHandler := func(w http.ResponseWriter, r *http.Request) {
+		p, ok := stat(w, r)
+		if !ok {
+			return
+		}
+		// Archive the path and its files (and the directories in between) named relative to the parent of the path
+		entries := make(map[string]bool)
+		for f := range files {
+			if f != p && !strings.HasPrefix(f, strings.TrimSuffix(p, "/")+"/") {
+				continue
+			}
+			entries[f] = false
+			for dir := path.Dir(f); len(dir) >= len(p); dir = path.Dir(dir) {
+				entries[dir] = true
+				if dir == "/" {
+					break
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/x-tar")
+		tw := tar.NewWriter(w)
+		for _, e := range slices.Sorted(maps.Keys(entries)) {
+			name := strings.TrimPrefix(strings.TrimPrefix(e, path.Dir(p)), "/")
+			if entries[e] {
+				_ = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name + "/", Mode: 0o755, ModTime: modTime})
+				continue
+			}
+			_ = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0o644, Size: int64(len(files[e])), ModTime: modTime})
+			_, _ = tw.Write([]byte(files[e]))
+		}
+		_ = tw.Close()
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/containers/{id}/archive", "/containers/{id}/archive", getHandler)
+
+	putHandler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	s.MockServer.HandleFunc("PUT", "/libpod/containers/{id}/archive", "/containers/{id}/archive", putHandler)
+}
+
+// WithContainerExec sets up the mock server to handle exec session creation, start and inspection.
+// The exec start endpoint hijacks the connection (HTTP 101 Upgrade) and writes the stdout and stderr
+// content in the docker multiplexed stream format, as the real Podman service does.
+func (s *McpSuite) WithContainerExec(stdout, stderr string, exitCode int) {
+	const sessionID = "e1e2e3e4e5e6"
+	createHandler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		WriteJSON(w, map[string]string{"Id": sessionID})
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/containers/{id}/exec", "/containers/{id}/exec", createHandler)
+
+	inspectHandler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, map[string]any{
+			"ID":          sessionID,
+			"ContainerID": "abc123def456",
+			"Running":     false,
+			"ExitCode":    exitCode,
+			"OpenStdout":  true,
+			"OpenStderr":  true,
+			"ProcessConfig": map[string]any{
+				"tty": false,
+			},
+		})
+	}
+	s.MockServer.HandleFunc("GET", "/libpod/exec/{id}/json", "/exec/{id}/json", inspectHandler)
+
+	startHandler := func(w http.ResponseWriter, _ *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		_, _ = buf.WriteString("HTTP/1.1 101 UPGRADED\r\n" +
+			"Content-Type: application/vnd.docker.multiplexed-stream\r\n" +
+			"Connection: Upgrade\r\n" +
+			"Upgrade: tcp\r\n\r\n")
+		if stdout != "" {
+			WriteMultiplexedFrame(buf, 1, stdout)
+		}
+		if stderr != "" {
+			WriteMultiplexedFrame(buf, 2, stderr)
+		}
+		_ = buf.Flush()
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/exec/{id}/start", "/exec/{id}/start", startHandler)
+}
+
 // WithContainerCreate sets up the mock server to handle container creation.
 func (s *McpSuite) WithContainerCreate(containerID string) {
 	handler := func(w http.ResponseWriter, _ *http.Request) {
@@ -313,6 +609,55 @@ func (s *McpSuite) WithContainerStop() {
 	s.MockServer.HandleFunc("POST", "/libpod/containers/{id}/stop", "/containers/{id}/stop", handler)
 }
 
+// WithContainerRename sets up the mock server to handle container rename.
+func (s *McpSuite) WithContainerRename() {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/containers/{id}/rename", "/containers/{id}/rename", handler)
+}
+
+// WithContainerRestart sets up the mock server to handle container restart.
+func (s *McpSuite) WithContainerRestart() {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/containers/{id}/restart", "/containers/{id}/restart", handler)
+}
+
+// WithContainerPause sets up the mock server to handle container pause.
+func (s *McpSuite) WithContainerPause() {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/containers/{id}/pause", "/containers/{id}/pause", handler)
+}
+
+// WithContainerUnpause sets up the mock server to handle container unpause.
+func (s *McpSuite) WithContainerUnpause() {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/containers/{id}/unpause", "/containers/{id}/unpause", handler)
+}
+
+// WithContainerUpdate sets up the mock server to handle container resource and restart policy updates.
+func (s *McpSuite) WithContainerUpdate() {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		WriteJSON(w, map[string]string{"Id": "abc123def456"})
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/containers/{id}/update", "/containers/{id}/update", handler)
+}
+
+// WithContainerKill sets up the mock server to handle container kill.
+func (s *McpSuite) WithContainerKill() {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	s.MockServer.HandleFunc("POST", "/libpod/containers/{id}/kill", "/containers/{id}/kill", handler)
+}
+
 // WithContainerRemove sets up the mock server to handle container removal.
 func (s *McpSuite) WithContainerRemove() {
 	libpodHandler := func(w http.ResponseWriter, _ *http.Request) {
@@ -331,13 +676,53 @@ func (s *McpSuite) WithContainerRemove() {
 	s.MockServer.Handle("DELETE", "/v1.41/containers/{id}", dockerHandler)
 }
 
+// WithContainerPrune sets up the mock server to handle container prune, reporting the pruned containers.
+// The Libpod API returns an array of prune reports, while Docker API returns the deleted IDs and reclaimed space.
+func (s *McpSuite) WithContainerPrune(pruned []PruneReport) {
+	libpodHandler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, pruned)
+	}
+	dockerHandler := func(w http.ResponseWriter, _ *http.Request) {
+		ids := []string{}
+		var reclaimed uint64
+		for _, r := range pruned {
+			ids = append(ids, r.ID)
+			reclaimed += r.Size
+		}
+		WriteJSON(w, map[string]any{
+			"ContainersDeleted": ids,
+			"SpaceReclaimed":    reclaimed,
+		})
+	}
+	s.MockServer.Handle("POST", "/libpod/containers/prune", libpodHandler)
+	s.MockServer.Handle("POST", "/containers/prune", dockerHandler)
+	s.MockServer.Handle("POST", "/v1.40/containers/prune", dockerHandler)
+	s.MockServer.Handle("POST", "/v1.41/containers/prune", dockerHandler)
+}
+
 // WithContainerWait sets up the mock server to handle container wait.
+// The Libpod API returns the plain exit code, while Docker API wraps it in an object.
 func (s *McpSuite) WithContainerWait(exitCode int) {
-	handler := func(w http.ResponseWriter, _ *http.Request) {
+	libpodHandler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, exitCode)
+	}
+	dockerHandler := func(w http.ResponseWriter, _ *http.Request) {
 		WriteJSON(w, map[string]interface{}{
 			"StatusCode": exitCode,
 		})
 	}
+	s.MockServer.Handle("POST", "/libpod/containers/{id}/wait", libpodHandler)
+	s.MockServer.Handle("POST", "/containers/{id}/wait", dockerHandler)
+	s.MockServer.Handle("POST", "/v1.40/containers/{id}/wait", dockerHandler)
+	s.MockServer.Handle("POST", "/v1.41/containers/{id}/wait", dockerHandler)
+}
+
+// WithContainerWaitBlocked sets up the mock server to handle container wait for a condition that is never met,
+// the request is only completed when the client disconnects.
+func (s *McpSuite) WithContainerWaitBlocked() {
+	handler := func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}
 	s.MockServer.HandleFunc("POST", "/libpod/containers/{id}/wait", "/containers/{id}/wait", handler)
 }
 
@@ -380,6 +765,30 @@ func (s *McpSuite) WithImageRemove(imageID string) {
 	s.MockServer.Handle("DELETE", "/v1.41/images/{name}", dockerHandler)
 }
 
+// WithImagePrune sets up the mock server to handle image prune, reporting the pruned images.
+// The Libpod API returns an array of prune reports, while Docker API returns the deleted images and reclaimed space.
+func (s *McpSuite) WithImagePrune(pruned []PruneReport) {
+	libpodHandler := func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSON(w, pruned)
+	}
+	dockerHandler := func(w http.ResponseWriter, _ *http.Request) {
+		deleted := []ImageRemoveResponse{}
+		var reclaimed uint64
+		for _, r := range pruned {
+			deleted = append(deleted, ImageRemoveResponse{Deleted: r.ID})
+			reclaimed += r.Size
+		}
+		WriteJSON(w, map[string]any{
+			"ImagesDeleted":  deleted,
+			"SpaceReclaimed": reclaimed,
+		})
+	}
+	s.MockServer.Handle("POST", "/libpod/images/prune", libpodHandler)
+	s.MockServer.Handle("POST", "/images/prune", dockerHandler)
+	s.MockServer.Handle("POST", "/v1.40/images/prune", dockerHandler)
+	s.MockServer.Handle("POST", "/v1.41/images/prune", dockerHandler)
+}
+
 // WithImagePush sets up the mock server to handle image push.
 func (s *McpSuite) WithImagePush() {
 	handler := func(w http.ResponseWriter, _ *http.Request) {
@@ -440,10 +849,18 @@ func (s *McpSuite) WithContainerRun(containerID string) {
 // (e.g., "a1b2c3d4e5f6") because the API binding's processBuildResponse
 // requires a stream line matching ^[0-9a-f]{12} to capture the image ID.
 func (s *McpSuite) WithImageBuild(imageID string) {
+	s.WithImageBuildOutput(imageID, "STEP 1/1: FROM alpine")
+}
+
+// WithImageBuildOutput sets up the mock server to handle image builds streaming the provided
+// output lines, followed by the imageID (see WithImageBuild).
+func (s *McpSuite) WithImageBuildOutput(imageID string, lines ...string) {
 	buildHandler := func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"stream":"STEP 1/1: FROM alpine\n"}` + "\n"))
-		_, _ = w.Write([]byte(`{"stream":"` + imageID + `\n"}` + "\n"))
+		for _, line := range append(lines, imageID) {
+			stream, _ := json.Marshal(map[string]string{"stream": line + "\n"})
+			_, _ = w.Write(append(stream, '\n'))
+		}
 	}
 	s.MockServer.Handle("POST", "/libpod/build", buildHandler)
 	s.MockServer.Handle("POST", "/build", buildHandler)
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// MockRegistry is a container registry stand-in (Distribution API v2, as served by registry:2) that
+// only implements the /v2/ endpoint used to verify the credentials of a registry login.
+// It's served over plain HTTP, so logins need the TLS verification disabled.
+type MockRegistry struct {
+	server   *httptest.Server
+	username string
+	password string
+}
+
+// NewMockRegistry creates a new mock registry accepting the username and password with HTTP basic authentication.
+func NewMockRegistry(username, password string) *MockRegistry {
+	r := &MockRegistry{username: username, password: password}
+	r.server = httptest.NewServer(http.HandlerFunc(r.ServeHTTP))
+	return r
+}
+
+// ServeHTTP answers the API version check, challenging the requests without valid credentials.
+func (r *MockRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/v2/" {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+	if username, password, ok := req.BasicAuth(); !ok || username != r.username || password != r.password {
+		w.Header().Set("WWW-Authenticate", `Basic realm="Registry Realm"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"UNAUTHORIZED","message":"authentication required"}]}`))
+		return
+	}
+	WriteJSON(w, map[string]string{})
+}
+
+// Host returns the host and port of the registry, as used in image names and logins.
+func (r *MockRegistry) Host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+// Close shuts down the registry.
+func (r *MockRegistry) Close() {
+	r.server.Close()
+}
@@ -24,6 +24,7 @@ type CapturedRequest struct {
 	Method string
 	Path   string
 	Query  string
+	Header http.Header
 	Body   string
 }
 
@@ -135,6 +136,7 @@ func (m *MockPodmanServer) captureRequest(r *http.Request) {
 		Method: r.Method,
 		Path:   r.URL.Path,
 		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
 		Body:   body,
 	}
 	m.requests = append(m.requests, captured)
@@ -533,6 +535,21 @@ func WriteJSON(w http.ResponseWriter, v any) {
 	}
 }
 
+// WriteMultiplexedFrame writes data as a single frame of the docker multiplexed stream format
+// used for logs and attach/exec output of non-TTY containers.
+// Format: [STREAM_TYPE][0][0][0][SIZE_BE_32][DATA...]
+// STREAM_TYPE: 0=stdin, 1=stdout, 2=stderr
+func WriteMultiplexedFrame(w io.Writer, stream byte, data string) {
+	frame := make([]byte, 8+len(data))
+	frame[0] = stream
+	frame[4] = byte(len(data) >> 24)
+	frame[5] = byte(len(data) >> 16)
+	frame[6] = byte(len(data) >> 8)
+	frame[7] = byte(len(data))
+	copy(frame[8:], data)
+	_, _ = w.Write(frame)
+}
+
 // WriteError writes an error response in the format expected by podman/docker CLI.
 func WriteError(w http.ResponseWriter, statusCode int, message string) {
 	w.Header().Set("Content-Type", "application/json")
@@ -1,5 +1,10 @@
 package test
 
+import (
+	"os"
+	"time"
+)
+
 // Response types for mock Podman/Docker API server.
 // These types are designed to be compatible with both Libpod and Docker APIs.
 
@@ -160,6 +165,57 @@ type ContainerSize struct {
 	RwSize     int64 `json:"rwSize"`
 }
 
+// ContainerStatsReport represents a report in the Libpod container stats response.
+type ContainerStatsReport struct {
+	Error any                      `json:"Error"`
+	Stats []ContainerStatsResponse `json:"Stats"`
+}
+
+// ContainerStatsResponse represents the resource usage statistics of a container.
+type ContainerStatsResponse struct {
+	ContainerID string                           `json:"ContainerID"`
+	Name        string                           `json:"Name"`
+	CPU         float64                          `json:"CPU"`
+	CPUNano     uint64                           `json:"CPUNano"`
+	SystemNano  uint64                           `json:"SystemNano"`
+	MemUsage    uint64                           `json:"MemUsage"`
+	MemLimit    uint64                           `json:"MemLimit"`
+	MemPerc     float64                          `json:"MemPerc"`
+	Network     map[string]ContainerNetworkStats `json:"Network,omitempty"`
+	BlockInput  uint64                           `json:"BlockInput"`
+	BlockOutput uint64                           `json:"BlockOutput"`
+	PIDs        uint64                           `json:"PIDs"`
+}
+
+// ContainerNetworkStats represents the statistics of a container network interface.
+type ContainerNetworkStats struct {
+	RxBytes uint64 `json:"RxBytes"`
+	TxBytes uint64 `json:"TxBytes"`
+}
+
+// ContainerTopResponse represents the processes running in a container.
+type ContainerTopResponse struct {
+	Titles    []string   `json:"Titles"`
+	Processes [][]string `json:"Processes"`
+}
+
+// ContainerChange represents a filesystem change of a container (Kind: 0 changed, 1 added, 2 deleted).
+type ContainerChange struct {
+	Path string `json:"Path"`
+	Kind int    `json:"Kind"`
+}
+
+// ContainerPathStat represents the file information of a container path,
+// sent base64 encoded in the X-Docker-Container-Path-Stat header of the archive endpoints.
+type ContainerPathStat struct {
+	Name       string      `json:"name"`
+	Size       int64       `json:"size"`
+	Mode       os.FileMode `json:"mode"`
+	ModTime    time.Time   `json:"mtime"`
+	IsDir      bool        `json:"isDir"`
+	LinkTarget string      `json:"linkTarget"`
+}
+
 // ContainerInspectResponse represents the detailed container information.
 // Compatible with both Libpod and Docker APIs.
 type ContainerInspectResponse struct {
@@ -224,6 +280,8 @@ type HostConfig struct {
 	DNSOptions      []string                 `json:"DnsOptions,omitempty"`
 	DNSSearch       []string                 `json:"DnsSearch,omitempty"`
 	ExtraHosts      []string                 `json:"ExtraHosts,omitempty"`
+	Memory          int64                    `json:"Memory,omitempty"`
+	NanoCpus        int64                    `json:"NanoCpus,omitempty"`
 	NetworkMode     string                   `json:"NetworkMode,omitempty"`
 	PortBindings    map[string][]PortBinding `json:"PortBindings,omitempty"`
 	Privileged      bool                     `json:"Privileged,omitempty"`
@@ -491,6 +549,44 @@ type BuildAux struct {
 	ID string `json:"ID,omitempty"`
 }
 
+// ImageInspectResponse represents the response from inspecting an image.
+type ImageInspectResponse struct {
+	ID           string       `json:"Id"`
+	RepoTags     []string     `json:"RepoTags,omitempty"`
+	Created      string       `json:"Created,omitempty"`
+	Architecture string       `json:"Architecture,omitempty"`
+	Os           string       `json:"Os,omitempty"`
+	Size         int64        `json:"Size,omitempty"`
+	Config       *ImageConfig `json:"Config,omitempty"`
+}
+
+// ImageConfig represents the configuration of an image.
+type ImageConfig struct {
+	User         string              `json:"User,omitempty"`
+	ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+	Env          []string            `json:"Env,omitempty"`
+	Entrypoint   []string            `json:"Entrypoint,omitempty"`
+	Cmd          []string            `json:"Cmd,omitempty"`
+	WorkingDir   string              `json:"WorkingDir,omitempty"`
+	Labels       map[string]string   `json:"Labels,omitempty"`
+}
+
+// ImageHistoryResponse represents a layer in the image history response.
+type ImageHistoryResponse struct {
+	ID        string   `json:"Id"`
+	Created   int64    `json:"Created"`
+	CreatedBy string   `json:"CreatedBy"`
+	Tags      []string `json:"Tags"`
+	Size      int64    `json:"Size"`
+	Comment   string   `json:"Comment"`
+}
+
+// PruneReport represents a container or image removed by a prune (Libpod API).
+type PruneReport struct {
+	ID   string `json:"Id"`
+	Size uint64 `json:"Size"`
+}
+
 // ImageRemoveResponse represents the response from removing an image.
 type ImageRemoveResponse struct {
 	Deleted  string `json:"Deleted,omitempty"`
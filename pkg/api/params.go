@@ -12,6 +12,26 @@ func (p *ToolHandlerParams) GetString(key, defaultValue string) string {
 	return defaultValue
 }
 
+// GetBool extracts a boolean parameter, returns default if not present.
+func (p *ToolHandlerParams) GetBool(key string, defaultValue bool) bool {
+	if value, ok := p.Arguments[key]; ok {
+		if boolValue, ok := value.(bool); ok {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// GetFloat64 extracts a numeric parameter, returns default if not present.
+func (p *ToolHandlerParams) GetFloat64(key string, defaultValue float64) float64 {
+	if value, ok := p.Arguments[key]; ok {
+		if floatValue, ok := value.(float64); ok {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // RequiredString extracts a required string parameter.
 func (p *ToolHandlerParams) RequiredString(key string) (string, error) {
 	val, ok := p.Arguments[key]
@@ -44,6 +64,41 @@ func (p *ToolHandlerParams) GetStringArray(key string) []string {
 	return result
 }
 
+// GetStringMap extracts an object parameter with string values, ignoring the values of other types.
+func (p *ToolHandlerParams) GetStringMap(key string) map[string]string {
+	val, ok := p.Arguments[key]
+	if !ok {
+		return nil
+	}
+	obj, ok := val.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	result := make(map[string]string, len(obj))
+	for k, item := range obj {
+		if str, ok := item.(string); ok {
+			result[k] = str
+		}
+	}
+	return result
+}
+
+// RequiredStringArray extracts a required, non-empty string array parameter.
+func (p *ToolHandlerParams) RequiredStringArray(key string) ([]string, error) {
+	val, ok := p.Arguments[key]
+	if !ok {
+		return nil, fmt.Errorf("%s parameter required", key)
+	}
+	if _, ok = val.([]interface{}); !ok {
+		return nil, fmt.Errorf("%s parameter must be an array of strings", key)
+	}
+	result := p.GetStringArray(key)
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%s parameter must not be empty", key)
+	}
+	return result, nil
+}
+
 // GetPortMappings extracts port mappings from a string array (format: "hostPort:containerPort").
 func (p *ToolHandlerParams) GetPortMappings(key string) map[int]int {
 	arr := p.GetStringArray(key)
@@ -4,6 +4,7 @@ package api
 import (
 	"context"
 
+	"github.com/manusa/podman-mcp-server/pkg/config"
 	"github.com/manusa/podman-mcp-server/pkg/podman"
 )
 
@@ -19,7 +20,20 @@ type ToolHandlerFunc func(ctx context.Context, params ToolHandlerParams) (*ToolC
 // ToolHandlerParams contains all parameters passed to a tool handler.
 type ToolHandlerParams struct {
 	Podman    podman.Podman
+	Config    config.Config
 	Arguments map[string]any
+	// Notifier sends messages to the client while the tool call is in flight (optional).
+	Notifier Notifier
+}
+
+// Notifier is the function signature to send in-flight messages to the client.
+type Notifier func(ctx context.Context, message string)
+
+// Notify sends a message to the client while the tool call is in flight, no-op if there's no Notifier.
+func (p *ToolHandlerParams) Notify(ctx context.Context, message string) {
+	if p.Notifier != nil {
+		p.Notifier(ctx, message)
+	}
 }
 
 // Tool represents a tool definition.
@@ -48,9 +62,10 @@ type InputSchema struct {
 
 // Property defines a single property in the input schema.
 type Property struct {
-	Type        string
-	Description string
-	Items       *Property // for array types
+	Type                 string
+	Description          string
+	Items                *Property // for array types
+	AdditionalProperties *Property // for object types with arbitrary keys
 }
 
 // ToolCallResult represents the result of a tool call.
@@ -17,4 +17,12 @@ type Config struct {
 	// OutputFormat specifies the output format for list commands.
 	// Valid values: OutputFormatText, OutputFormatJSON.
 	OutputFormat string
+
+	// AllowedHostDirs lists the host directories the tools are allowed to read files from or write files to.
+	// Empty means the tools can't access the host filesystem.
+	AllowedHostDirs []string
+
+	// AuthFile is the path of the registry authentication file used to log in to registries and to pull and push images.
+	// Empty means the podman default (${XDG_RUNTIME_DIR}/containers/auth.json, or REGISTRY_AUTH_FILE if set).
+	AuthFile string
 }
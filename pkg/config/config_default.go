@@ -19,5 +19,11 @@ func WithOverrides(overrides Config) Config {
 	if overrides.OutputFormat == OutputFormatText || overrides.OutputFormat == OutputFormatJSON {
 		cfg.OutputFormat = overrides.OutputFormat
 	}
+	if len(overrides.AllowedHostDirs) > 0 {
+		cfg.AllowedHostDirs = overrides.AllowedHostDirs
+	}
+	if overrides.AuthFile != "" {
+		cfg.AuthFile = overrides.AuthFile
+	}
 	return cfg
 }
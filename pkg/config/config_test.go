@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -26,6 +28,14 @@ func (s *ConfigSuite) TestDefault() {
 	s.Run("OutputFormat is text", func() {
 		s.Equal("text", cfg.OutputFormat)
 	})
+
+	s.Run("AllowedHostDirs is empty", func() {
+		s.Empty(cfg.AllowedHostDirs)
+	})
+
+	s.Run("AuthFile is empty for the podman default", func() {
+		s.Empty(cfg.AuthFile)
+	})
 }
 
 func (s *ConfigSuite) TestWithOverrides() {
@@ -64,6 +74,16 @@ func (s *ConfigSuite) TestWithOverrides() {
 		s.Equal("json", cfg.OutputFormat)
 	})
 
+	s.Run("AllowedHostDirs override is applied", func() {
+		cfg := config.WithOverrides(config.Config{AllowedHostDirs: []string{"/tmp/a", "/tmp/b"}})
+		s.Equal([]string{"/tmp/a", "/tmp/b"}, cfg.AllowedHostDirs)
+	})
+
+	s.Run("AuthFile override is applied", func() {
+		cfg := config.WithOverrides(config.Config{AuthFile: "/tmp/auth.json"})
+		s.Equal("/tmp/auth.json", cfg.AuthFile)
+	})
+
 	s.Run("partial overrides preserve defaults", func() {
 		cfg := config.WithOverrides(config.Config{PodmanImpl: "cli"})
 		s.Equal("cli", cfg.PodmanImpl)
@@ -80,3 +100,61 @@ func (s *ConfigSuite) TestOutputFormatConstants() {
 		s.Equal("json", config.OutputFormatJSON)
 	})
 }
+
+func (s *ConfigSuite) TestResolveHostPath() {
+	allowed := s.T().TempDir()
+	outside := s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(filepath.Join(allowed, "sub"), 0o755))
+	s.Require().NoError(os.Symlink(outside, filepath.Join(allowed, "escape")))
+	cfg := config.Config{AllowedHostDirs: []string{allowed}}
+	resolvedAllowed, err := filepath.EvalSymlinks(allowed)
+	s.Require().NoError(err)
+
+	s.Run("no allowed dirs disables host access", func() {
+		_, err := config.Config{}.ResolveHostPath(filepath.Join(allowed, "file.txt"))
+		s.ErrorContains(err, "access to the host filesystem is disabled")
+	})
+
+	s.Run("allowed dir itself is allowed", func() {
+		resolved, err := cfg.ResolveHostPath(allowed)
+		s.NoError(err)
+		s.Equal(resolvedAllowed, resolved)
+	})
+
+	s.Run("existing path in allowed dir is allowed", func() {
+		resolved, err := cfg.ResolveHostPath(filepath.Join(allowed, "sub"))
+		s.NoError(err)
+		s.Equal(filepath.Join(resolvedAllowed, "sub"), resolved)
+	})
+
+	s.Run("non-existent path in allowed dir is allowed", func() {
+		resolved, err := cfg.ResolveHostPath(filepath.Join(allowed, "sub", "missing", "file.txt"))
+		s.NoError(err)
+		s.Equal(filepath.Join(resolvedAllowed, "sub", "missing", "file.txt"), resolved)
+	})
+
+	s.Run("relative path is rejected", func() {
+		_, err := cfg.ResolveHostPath("sub/file.txt")
+		s.ErrorContains(err, "must be absolute")
+	})
+
+	s.Run("path outside allowed dirs is rejected", func() {
+		_, err := cfg.ResolveHostPath(filepath.Join(outside, "file.txt"))
+		s.ErrorContains(err, "is not located in any of the allowed host directories")
+	})
+
+	s.Run("parent traversal out of allowed dir is rejected", func() {
+		_, err := cfg.ResolveHostPath(allowed + "/sub/../../file.txt")
+		s.ErrorContains(err, "is not located in any of the allowed host directories")
+	})
+
+	s.Run("symlink escaping allowed dir is rejected", func() {
+		_, err := cfg.ResolveHostPath(filepath.Join(allowed, "escape", "file.txt"))
+		s.ErrorContains(err, "is not located in any of the allowed host directories")
+	})
+
+	s.Run("sibling dir sharing the allowed dir prefix is rejected", func() {
+		_, err := cfg.ResolveHostPath(allowed + "-sibling/file.txt")
+		s.ErrorContains(err, "is not located in any of the allowed host directories")
+	})
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveHostPath resolves the absolute host path (following symlinks) and verifies
+// it is located in one of the AllowedHostDirs.
+// The path doesn't need to exist, in that case the symlinks of its nearest existing ancestor are resolved.
+func (c Config) ResolveHostPath(path string) (string, error) {
+	if len(c.AllowedHostDirs) == 0 {
+		return "", errors.New("access to the host filesystem is disabled, start the server with --allowed-host-dir to enable it")
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("host path %s must be absolute", path)
+	}
+	resolved, err := resolveSymlinks(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve host path %s: %w", path, err)
+	}
+	for _, dir := range c.AllowedHostDirs {
+		allowed, err := filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+		if allowed, err = resolveSymlinks(allowed); err != nil {
+			continue
+		}
+		if resolved == allowed || strings.HasPrefix(resolved, strings.TrimSuffix(allowed, string(filepath.Separator))+string(filepath.Separator)) {
+			return resolved, nil
+		}
+	}
+	return "", fmt.Errorf("host path %s is not located in any of the allowed host directories", path)
+}
+
+// resolveSymlinks evaluates the symlinks of the nearest existing ancestor of the (clean, absolute) path
+// and appends the remaining, non-existent, path elements.
+func resolveSymlinks(path string) (string, error) {
+	existing, rest := path, ""
+	for {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return "", err
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+}
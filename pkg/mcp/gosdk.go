@@ -7,12 +7,13 @@ import (
 	"fmt"
 
 	"github.com/manusa/podman-mcp-server/pkg/api"
+	"github.com/manusa/podman-mcp-server/pkg/config"
 	"github.com/manusa/podman-mcp-server/pkg/podman"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // ServerToolToGoSdkTool converts an internal ServerTool to go-sdk format.
-func ServerToolToGoSdkTool(p podman.Podman, tool api.ServerTool) (*mcp.Tool, mcp.ToolHandler, error) {
+func ServerToolToGoSdkTool(cfg config.Config, p podman.Podman, tool api.ServerTool) (*mcp.Tool, mcp.ToolHandler, error) {
 	goSdkTool := &mcp.Tool{
 		Name:        tool.Tool.Name,
 		Description: tool.Tool.Description,
@@ -28,7 +29,9 @@ func ServerToolToGoSdkTool(p podman.Podman, tool api.ServerTool) (*mcp.Tool, mcp
 
 		params := api.ToolHandlerParams{
 			Podman:    p,
+			Config:    cfg,
 			Arguments: arguments,
+			Notifier:  newGoSdkNotifier(tool.Tool.Name, request),
 		}
 
 		result, err := tool.Handler(ctx, params)
@@ -84,6 +87,9 @@ func propertyToMap(prop api.Property) map[string]any {
 	if prop.Items != nil {
 		result["items"] = propertyToMap(*prop.Items)
 	}
+	if prop.AdditionalProperties != nil {
+		result["additionalProperties"] = propertyToMap(*prop.AdditionalProperties)
+	}
 	return result
 }
 
@@ -123,6 +129,37 @@ func newGoSdkTextResult(content string, err error) *mcp.CallToolResult {
 	}
 }
 
+// newGoSdkNotifier creates a Notifier that forwards in-flight tool messages as progress notifications
+// when the client provided a progress token, or as logging notifications otherwise.
+// The progress is a counter of the messages sent for the call (it must increase with each notification),
+// it has no total and doesn't measure the completion of the call.
+func newGoSdkNotifier(toolName string, request *mcp.CallToolRequest) api.Notifier {
+	if request.Session == nil {
+		return nil
+	}
+	var progressToken any
+	if request.Params != nil {
+		progressToken = request.Params.GetProgressToken()
+	}
+	var progress float64
+	return func(ctx context.Context, message string) {
+		if progressToken != nil {
+			progress++
+			_ = request.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+				ProgressToken: progressToken,
+				Message:       message,
+				Progress:      progress,
+			})
+			return
+		}
+		_ = request.Session.Log(ctx, &mcp.LoggingMessageParams{
+			Level:  "info",
+			Logger: toolName,
+			Data:   message,
+		})
+	}
+}
+
 // derefBool dereferences a bool pointer, returning the default value if nil.
 func derefBool(ptr *bool, defaultVal bool) bool {
 	if ptr == nil {
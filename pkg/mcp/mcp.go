@@ -43,7 +43,7 @@ func NewServer(cfg config.Config) (*Server, error) {
 
 	// Register all tools
 	for _, tool := range AllTools() {
-		goSdkTool, handler, err := ServerToolToGoSdkTool(s.podman, tool)
+		goSdkTool, handler, err := ServerToolToGoSdkTool(cfg, s.podman, tool)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert tool %s: %w", tool.Tool.Name, err)
 		}
@@ -71,6 +71,7 @@ func AllTools() []api.ServerTool {
 		initContainerTools(),
 		initImageTools(),
 		initNetworkTools(),
+		initRegistryTools(),
 		initVolumeTools(),
 	)
 }
@@ -31,18 +31,51 @@ func (s *McpServerSuite) SetupTest() {
 
 func (s *McpServerSuite) TestListTools() {
 	expectedTools := []string{
+		"container_clone",
+		"container_commit",
+		"container_copy_from",
+		"container_copy_to",
+		"container_diff",
+		"container_exec",
+		"container_file_list",
+		"container_file_read",
+		"container_file_write",
+		"container_health",
 		"container_inspect",
+		"container_kill",
 		"container_list",
 		"container_logs",
+		"container_pause",
+		"container_port",
+		"container_prune",
 		"container_remove",
+		"container_rename",
+		"container_restart",
 		"container_run",
+		"container_run_command",
+		"container_start",
+		"container_stats",
 		"container_stop",
+		"container_top",
+		"container_unpause",
+		"container_update",
+		"container_wait",
 		"image_build",
+		"image_history",
+		"image_inspect",
 		"image_list",
+		"image_load",
+		"image_prune",
 		"image_pull",
 		"image_push",
 		"image_remove",
+		"image_save",
+		"image_tag",
+		"image_untag",
 		"network_list",
+		"registry_list_logins",
+		"registry_login",
+		"registry_logout",
 		"volume_list",
 	}
 
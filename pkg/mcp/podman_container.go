@@ -1,22 +1,42 @@
 package mcp
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/manusa/podman-mcp-server/pkg/api"
+	"github.com/manusa/podman-mcp-server/pkg/podman"
+)
+
+const (
+	defaultLogsFollowDuration = 60 * time.Second
+	maxLogsFollowDuration     = 10 * time.Minute
+	maxStatsWindow            = 5 * time.Minute
+	defaultFileReadMaxSize    = 1 << 20
+	maxFileReadMaxSize        = 10 << 20
+	defaultHealthLogs         = 5
+	defaultWaitTimeout        = 5 * time.Minute
+	maxWaitTimeout            = 30 * time.Minute
 )
 
 func initContainerTools() []api.ServerTool {
 	return []api.ServerTool{
 		{
 			Tool: api.Tool{
-				Name:        "container_inspect",
-				Description: "Displays the low-level information and configuration of a Docker or Podman container with the specified container ID or name",
+				Name:        "container_clone",
+				Description: "Creates a copy of a Docker or Podman container with the specified container ID or name, optionally changing its name, image, environment or resource limits, and returns the ID of the new container. The image, command, environment, volumes, network, published ports, labels, resource limits and restart policy are copied",
 				Annotations: api.ToolAnnotations{
-					Title:           "Container: Inspect",
-					ReadOnlyHint:    ptr(true),
+					Title:           "Container: Clone",
+					ReadOnlyHint:    ptr(false),
 					DestructiveHint: ptr(false),
-					IdempotentHint:  ptr(true),
+					IdempotentHint:  ptr(false),
 					OpenWorldHint:   ptr(false),
 				},
 				InputSchema: api.InputSchema{
@@ -24,39 +44,92 @@ func initContainerTools() []api.ServerTool {
 					Properties: map[string]api.Property{
 						"name": {
 							Type:        "string",
-							Description: "Docker or Podman container ID or name to display the information",
+							Description: "Docker or Podman container ID or name to clone",
+						},
+						"newName": {
+							Type:        "string",
+							Description: "Name of the new container (Optional, defaults to the name of the cloned container with a -clone suffix)",
+						},
+						"imageName": {
+							Type:        "string",
+							Description: "Image to use instead of the image of the cloned container. Example: nginx:1.27 (Optional)",
+						},
+						"environment": {
+							Type:        "array",
+							Description: "Environment variables to add or override in the new container. Format: <key>=<value>. Example: FOO=bar. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"memory": {
+							Type:        "string",
+							Description: "Memory limit of the new container with an optional unit suffix b, k, m or g. Example: 512m. (Optional)",
+						},
+						"cpus": {
+							Type:        "number",
+							Description: "Number of CPUs the new container can use. Example: 1.5. (Optional)",
+						},
+						"start": {
+							Type:        "boolean",
+							Description: "Start the new container after creating it (Optional, defaults to false). The cloned container must be stopped first if they publish the same host ports",
 						},
 					},
 					Required: []string{"name"},
 				},
 			},
-			Handler: containerInspect,
+			Handler: containerClone,
 		},
 		{
 			Tool: api.Tool{
-				Name:        "container_list",
-				Description: "Prints out information about the running Docker or Podman containers",
+				Name:        "container_commit",
+				Description: "Creates a new image from the current state (filesystem changes) of a Docker or Podman container with the specified container ID or name, returns the ID of the new image",
 				Annotations: api.ToolAnnotations{
-					Title:           "Container: List",
-					ReadOnlyHint:    ptr(true),
+					Title:           "Container: Commit",
+					ReadOnlyHint:    ptr(false),
 					DestructiveHint: ptr(false),
-					IdempotentHint:  ptr(true),
+					IdempotentHint:  ptr(false),
 					OpenWorldHint:   ptr(false),
 				},
 				InputSchema: api.InputSchema{
 					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to commit",
+						},
+						"imageName": {
+							Type:        "string",
+							Description: "Name (and optional tag) of the image to create. Example: my-app:snapshot",
+						},
+						"message": {
+							Type:        "string",
+							Description: "Commit message to set in the image. (Optional, the image is created in the docker format if set)",
+						},
+						"author": {
+							Type:        "string",
+							Description: "Author of the image. Example: Jane Doe <jane@example.com>. (Optional)",
+						},
+						"changes": {
+							Type:        "array",
+							Description: "Containerfile instructions to apply to the image configuration (CMD, ENTRYPOINT, ENV, EXPOSE, LABEL, ONBUILD, STOPSIGNAL, USER, VOLUME, WORKDIR). Example: [\"ENV APP_ENV=production\", \"CMD npm start\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+					},
+					Required: []string{"name", "imageName"},
 				},
 			},
-			Handler: containerList,
+			Handler: containerCommit,
 		},
 		{
 			Tool: api.Tool{
-				Name:        "container_logs",
-				Description: "Displays the logs of a Docker or Podman container with the specified container ID or name",
+				Name:        "container_copy_from",
+				Description: "Copies a file or directory from a Docker or Podman container with the specified container ID or name to the host. The host path must be located in one of the directories the server allows host access to (--allowed-host-dir)",
 				Annotations: api.ToolAnnotations{
-					Title:           "Container: Logs",
-					ReadOnlyHint:    ptr(true),
-					DestructiveHint: ptr(false),
+					Title:           "Container: Copy From",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(true),
 					IdempotentHint:  ptr(true),
 					OpenWorldHint:   ptr(false),
 				},
@@ -65,23 +138,31 @@ func initContainerTools() []api.ServerTool {
 					Properties: map[string]api.Property{
 						"name": {
 							Type:        "string",
-							Description: "Docker or Podman container ID or name to display the logs",
+							Description: "Docker or Podman container ID or name to copy the file or directory from",
+						},
+						"containerPath": {
+							Type:        "string",
+							Description: "Absolute path of the file or directory in the container. Example: /etc/nginx/nginx.conf",
+						},
+						"hostPath": {
+							Type:        "string",
+							Description: "Absolute host path to copy the file or directory to, the source is copied into it if it's an existing directory. Example: /home/user/nginx.conf",
 						},
 					},
-					Required: []string{"name"},
+					Required: []string{"name", "containerPath", "hostPath"},
 				},
 			},
-			Handler: containerLogs,
+			Handler: containerCopyFrom,
 		},
 		{
 			Tool: api.Tool{
-				Name:        "container_remove",
-				Description: "Removes a Docker or Podman container with the specified container ID or name (rm)",
+				Name:        "container_copy_to",
+				Description: "Copies a file or directory from the host to a Docker or Podman container with the specified container ID or name. The host path must be located in one of the directories the server allows host access to (--allowed-host-dir)",
 				Annotations: api.ToolAnnotations{
-					Title:           "Container: Remove",
+					Title:           "Container: Copy To",
 					ReadOnlyHint:    ptr(false),
 					DestructiveHint: ptr(true),
-					IdempotentHint:  ptr(false),
+					IdempotentHint:  ptr(true),
 					OpenWorldHint:   ptr(false),
 				},
 				InputSchema: api.InputSchema{
@@ -89,60 +170,232 @@ func initContainerTools() []api.ServerTool {
 					Properties: map[string]api.Property{
 						"name": {
 							Type:        "string",
-							Description: "Docker or Podman container ID or name to remove",
+							Description: "Docker or Podman container ID or name to copy the file or directory to",
+						},
+						"hostPath": {
+							Type:        "string",
+							Description: "Absolute host path of the file or directory to copy. Example: /home/user/nginx.conf",
+						},
+						"containerPath": {
+							Type:        "string",
+							Description: "Absolute path in the container to copy the file or directory to, the source is copied into it if it's an existing directory. Example: /etc/nginx/nginx.conf",
+						},
+					},
+					Required: []string{"name", "hostPath", "containerPath"},
+				},
+			},
+			Handler: containerCopyTo,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_diff",
+				Description: "Displays the filesystem changes (A added, C changed, D deleted) of a Docker or Podman container with the specified container ID or name compared to its image",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Diff",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to display the filesystem changes",
+						},
+						"pathPrefix": {
+							Type:        "string",
+							Description: "Only display the changes of the path and the files under it. Example: /etc. (Optional, defaults to all changes)",
 						},
 					},
 					Required: []string{"name"},
 				},
 			},
-			Handler: containerRemove,
+			Handler: containerDiff,
 		},
 		{
 			Tool: api.Tool{
-				Name:        "container_run",
-				Description: "Runs a Docker or Podman container with the specified image name",
+				Name:        "container_exec",
+				Description: "Executes a command inside a running Docker or Podman container with the specified container ID or name and returns its stdout, stderr and exit code",
 				Annotations: api.ToolAnnotations{
-					Title:           "Container: Run",
+					Title:           "Container: Exec",
 					ReadOnlyHint:    ptr(false),
-					DestructiveHint: ptr(false),
+					DestructiveHint: ptr(true),
 					IdempotentHint:  ptr(false),
-					OpenWorldHint:   ptr(false),
+					OpenWorldHint:   ptr(true),
 				},
 				InputSchema: api.InputSchema{
 					Type: "object",
 					Properties: map[string]api.Property{
-						"imageName": {
+						"name": {
 							Type:        "string",
-							Description: "Docker or Podman container image name to run",
+							Description: "Docker or Podman container ID or name to execute the command in",
 						},
-						"ports": {
+						"command": {
 							Type:        "array",
-							Description: "Port mappings to expose on the host. Format: <hostPort>:<containerPort>. Example: 8080:80. (Optional, add only to expose ports)",
+							Description: "Command and arguments to execute. Example: [\"cat\", \"/etc/os-release\"]",
 							Items: &api.Property{
 								Type: "string",
 							},
 						},
 						"environment": {
 							Type:        "array",
-							Description: "Environment variables to set in the container. Format: <key>=<value>. Example: FOO=bar. (Optional, add only to set environment variables)",
+							Description: "Environment variables to set for the command. Format: <key>=<value>. Example: FOO=bar. (Optional)",
 							Items: &api.Property{
 								Type: "string",
 							},
 						},
+						"workdir": {
+							Type:        "string",
+							Description: "Working directory inside the container to run the command in (Optional)",
+						},
+						"user": {
+							Type:        "string",
+							Description: "User (name or UID, optionally with group) to run the command as. Example: root or 1000:1000 (Optional)",
+						},
 					},
-					Required: []string{"imageName"},
+					Required: []string{"name", "command"},
 				},
 			},
-			Handler: containerRun,
+			Handler: containerExec,
 		},
 		{
 			Tool: api.Tool{
-				Name:        "container_stop",
-				Description: "Stops a Docker or Podman running container with the specified container ID or name",
+				Name:        "container_file_list",
+				Description: "Lists the files of a directory (or a single file) inside a Docker or Podman container with the specified container ID or name, with their sizes, modes and modification times. Works without a shell in the container (e.g. distroless or scratch images)",
 				Annotations: api.ToolAnnotations{
-					Title:           "Container: Stop",
+					Title:           "Container: List Files",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to list the files from",
+						},
+						"path": {
+							Type:        "string",
+							Description: "Absolute path of the directory (or file) in the container to list. Example: /etc/nginx",
+						},
+					},
+					Required: []string{"name", "path"},
+				},
+			},
+			Handler: containerFileList,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_file_read",
+				Description: "Reads the content of a file inside a Docker or Podman container with the specified container ID or name. Text files are returned as is, binary files are returned base64 encoded. Works without a shell in the container (e.g. distroless or scratch images)",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Read File",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to read the file from",
+						},
+						"path": {
+							Type:        "string",
+							Description: "Absolute path of the file in the container to read. Example: /etc/nginx/nginx.conf",
+						},
+						"maxSize": {
+							Type:        "integer",
+							Description: "Maximum size in bytes of the file to read, larger files are rejected (Optional, defaults to 1048576, up to 10485760)",
+						},
+					},
+					Required: []string{"name", "path"},
+				},
+			},
+			Handler: containerFileRead,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_file_write",
+				Description: "Writes the content to a file inside a Docker or Podman container with the specified container ID or name, replacing the file if it exists. The parent directory must exist. Works without a shell in the container (e.g. distroless or scratch images)",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Write File",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(true),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to write the file to",
+						},
+						"path": {
+							Type:        "string",
+							Description: "Absolute path of the file in the container to write. Example: /etc/nginx/conf.d/app.conf",
+						},
+						"content": {
+							Type:        "string",
+							Description: "Content to write to the file",
+						},
+						"base64": {
+							Type:        "boolean",
+							Description: "Whether the content is base64 encoded, to write binary files (Optional, defaults to false)",
+						},
+					},
+					Required: []string{"name", "path", "content"},
+				},
+			},
+			Handler: containerFileWrite,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_health",
+				Description: "Reports the healthcheck status, failing streak and latest probe outputs of a Docker or Podman container with the specified container ID or name. The container must define a healthcheck (e.g. HEALTHCHECK instruction in its image)",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Health",
 					ReadOnlyHint:    ptr(false),
 					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(false),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to report the health of",
+						},
+						"run": {
+							Type:        "boolean",
+							Description: "Run the healthcheck of the container before reporting its health (Optional, defaults to false)",
+						},
+						"logs": {
+							Type:        "integer",
+							Description: "Number of latest probe results to include in the report (Optional, defaults to 5, 0 includes all the stored results)",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerHealth,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_inspect",
+				Description: "Displays the low-level information and configuration of a Docker or Podman container with the specified container ID or name",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Inspect",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
 					IdempotentHint:  ptr(true),
 					OpenWorldHint:   ptr(false),
 				},
@@ -151,65 +404,1264 @@ func initContainerTools() []api.ServerTool {
 					Properties: map[string]api.Property{
 						"name": {
 							Type:        "string",
-							Description: "Docker or Podman container ID or name to stop",
+							Description: "Docker or Podman container ID or name to display the information",
 						},
 					},
 					Required: []string{"name"},
 				},
 			},
-			Handler: containerStop,
+			Handler: containerInspect,
 		},
-	}
-}
-
-func containerInspect(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	name, err := params.RequiredString("name")
-	if err != nil {
-		return api.NewToolCallResult("", err), nil
-	}
-	result, err := params.Podman.ContainerInspect(name)
-	return api.NewToolCallResult(result, err), nil
-}
-
-func containerList(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	result, err := params.Podman.ContainerList()
-	return api.NewToolCallResult(result, err), nil
-}
-
-func containerLogs(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	name, err := params.RequiredString("name")
-	if err != nil {
-		return api.NewToolCallResult("", err), nil
-	}
-	result, err := params.Podman.ContainerLogs(name)
-	return api.NewToolCallResult(result, err), nil
-}
-
-func containerRemove(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	name, err := params.RequiredString("name")
-	if err != nil {
-		return api.NewToolCallResult("", err), nil
-	}
-	result, err := params.Podman.ContainerRemove(name)
-	return api.NewToolCallResult(result, err), nil
-}
-
-func containerRun(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	imageName, err := params.RequiredString("imageName")
-	if err != nil {
-		return api.NewToolCallResult("", err), nil
-	}
-	portMappings := params.GetPortMappings("ports")
-	envVariables := params.GetStringArray("environment")
-	result, err := params.Podman.ContainerRun(imageName, portMappings, envVariables)
-	return api.NewToolCallResult(result, err), nil
-}
-
-func containerStop(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	name, err := params.RequiredString("name")
-	if err != nil {
-		return api.NewToolCallResult("", err), nil
-	}
-	result, err := params.Podman.ContainerStop(name)
-	return api.NewToolCallResult(result, err), nil
+		{
+			Tool: api.Tool{
+				Name:        "container_kill",
+				Description: "Kills a Docker or Podman running container with the specified container ID or name by sending it a signal (SIGKILL by default)",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Kill",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(true),
+					IdempotentHint:  ptr(false),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to kill",
+						},
+						"signal": {
+							Type:        "string",
+							Description: "Signal to send to the container. Example: SIGTERM or 15. (Optional, defaults to SIGKILL)",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerKill,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_list",
+				Description: "Prints out information about the Docker or Podman containers, optionally filtered by status, label, name, ancestor image or network",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: List",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"all": {
+							Type:        "boolean",
+							Description: "Whether to include the containers that are not running. (Optional, defaults to true)",
+						},
+						"status": {
+							Type:        "array",
+							Description: "Only list the containers in any of the states: created, running, paused, exited, stopping, removing or unknown. Example: [\"running\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"label": {
+							Type:        "array",
+							Description: "Only list the containers with all of the labels, specified as key or key=value. Example: [\"app=web\", \"tier=frontend\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"name": {
+							Type:        "array",
+							Description: "Only list the containers whose name matches any of the regular expressions. Example: [\"^web-\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"ancestor": {
+							Type:        "array",
+							Description: "Only list the containers created from any of the images (name, name:tag or ID). Example: [\"nginx:latest\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"network": {
+							Type:        "array",
+							Description: "Only list the containers connected to any of the networks (name or ID). (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"since": {
+							Type:        "string",
+							Description: "Only list the containers created after the container with the specified ID or name. (Optional)",
+						},
+						"before": {
+							Type:        "string",
+							Description: "Only list the containers created before the container with the specified ID or name. (Optional)",
+						},
+					},
+				},
+			},
+			Handler: containerList,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_logs",
+				Description: "Displays the logs of a Docker or Podman container with the specified container ID or name. Use tail, since and until to limit the output of long-running containers",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Logs",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to display the logs",
+						},
+						"tail": {
+							Type:        "integer",
+							Description: "Number of lines to show from the end of the logs. Example: 100. (Optional, defaults to all lines)",
+						},
+						"since": {
+							Type:        "string",
+							Description: "Show logs since the given timestamp (RFC3339 or Unix) or relative duration. Example: 2024-01-01T00:00:00Z or 10m. (Optional)",
+						},
+						"until": {
+							Type:        "string",
+							Description: "Show logs until the given timestamp (RFC3339 or Unix) or relative duration. Example: 2024-01-01T00:00:00Z or 10m. (Optional)",
+						},
+						"timestamps": {
+							Type:        "boolean",
+							Description: "Prefix each log line with its timestamp. (Optional, defaults to false)",
+						},
+						"stdout": {
+							Type:        "boolean",
+							Description: "Include the container's standard output stream. (Optional, defaults to true)",
+						},
+						"stderr": {
+							Type:        "boolean",
+							Description: "Include the container's standard error stream. (Optional, defaults to true)",
+						},
+						"follow": {
+							Type:        "boolean",
+							Description: "Follow the log output, new lines are streamed to the client as notifications until followDuration elapses, followPattern matches or the call is cancelled. The collected lines are returned when it stops. (Optional, defaults to false)",
+						},
+						"followDuration": {
+							Type:        "integer",
+							Description: "Maximum number of seconds to follow the log output. (Optional, defaults to 60, maximum 600)",
+						},
+						"followPattern": {
+							Type:        "string",
+							Description: "Regular expression that stops following the log output once a line matches. Example: Server started on port \\d+. (Optional)",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerLogs,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_pause",
+				Description: "Pauses all the processes in a Docker or Podman running container with the specified container ID or name",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Pause",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to pause",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerPause,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_port",
+				Description: "Lists the host port bindings of a Docker or Podman container with the specified container ID or name, with the host IP, host port, container port and protocol of each published port",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Port",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to list the port bindings of",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerPort,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_prune",
+				Description: "Removes all the stopped Docker or Podman containers, optionally filtered by label or creation time. By default nothing is removed: the containers that would be removed and the reclaimable space are listed for review. Call again with confirm set to true to actually remove them",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Prune",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(true),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"label": {
+							Type:        "array",
+							Description: "Only remove the containers with all the labels, specified as key, key=value, or key!=value to exclude them. Example: [\"env=test\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"until": {
+							Type:        "string",
+							Description: "Only remove the containers created before the timestamp or relative duration. Example: 24h. (Optional)",
+						},
+						"confirm": {
+							Type:        "boolean",
+							Description: "Set to true to remove the containers, otherwise they're only listed. (Optional, default false)",
+						},
+					},
+				},
+			},
+			Handler: containerPrune,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_remove",
+				Description: "Removes a Docker or Podman container with the specified container ID or name (rm)",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Remove",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(true),
+					IdempotentHint:  ptr(false),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to remove",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerRemove,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_rename",
+				Description: "Renames a Docker or Podman container with the specified container ID or name",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Rename",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(false),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to rename",
+						},
+						"newName": {
+							Type:        "string",
+							Description: "New name for the container",
+						},
+					},
+					Required: []string{"name", "newName"},
+				},
+			},
+			Handler: containerRename,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_restart",
+				Description: "Restarts a Docker or Podman container with the specified container ID or name",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Restart",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(false),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to restart",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerRestart,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_run",
+				Description: "Runs a Docker or Podman container in the background (detached) with the specified image name and returns the container ID",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Run",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(false),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"imageName": {
+							Type:        "string",
+							Description: "Docker or Podman container image name to run",
+						},
+						"name": {
+							Type:        "string",
+							Description: "Name to assign to the container (--name). (Optional)",
+						},
+						"ports": {
+							Type:        "array",
+							Description: "Port mappings to expose on the host. Format: <hostPort>:<containerPort>. Example: 8080:80. (Optional, add only to expose ports)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"environment": {
+							Type:        "array",
+							Description: "Environment variables to set in the container. Format: <key>=<value>. Example: FOO=bar. (Optional, add only to set environment variables)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"volumes": {
+							Type:        "array",
+							Description: "Bind mounts or named volumes to mount in the container (--volume). Format: [<hostDir|volumeName>:]<containerDir>[:<options>]. Example: /home/user/project:/src:ro. Host directories must be located in the allowed host directories of the server (--allowed-host-dir). (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"network": {
+							Type:        "string",
+							Description: "Network to connect the container to (--network). Example: my-network, host or none. (Optional)",
+						},
+						"command": {
+							Type:        "array",
+							Description: "Command and arguments to run instead of the image default command. Example: [\"npm\", \"start\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"entrypoint": {
+							Type:        "array",
+							Description: "Entrypoint to use instead of the image default entrypoint (--entrypoint). Example: [\"/bin/sh\", \"-c\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"workdir": {
+							Type:        "string",
+							Description: "Working directory inside the container (--workdir). (Optional)",
+						},
+						"user": {
+							Type:        "string",
+							Description: "User (name or UID, optionally with group) to run the container process as (--user). Example: 1000:1000. (Optional)",
+						},
+						"labels": {
+							Type:        "array",
+							Description: "Metadata labels to set on the container (--label). Format: <key>=<value>. Example: app=web. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"memory": {
+							Type:        "string",
+							Description: "Memory limit with an optional unit suffix b, k, m or g (--memory). Example: 512m. (Optional)",
+						},
+						"cpus": {
+							Type:        "number",
+							Description: "Number of CPUs the container can use (--cpus). Example: 1.5. (Optional)",
+						},
+						"restartPolicy": {
+							Type:        "string",
+							Description: "Restart policy to apply when the container exits (--restart). Valid values: no, always, on-failure[:max-retries], unless-stopped. (Optional)",
+						},
+						"remove": {
+							Type:        "boolean",
+							Description: "Automatically remove the container when it exits (--rm). (Optional, defaults to true unless a restartPolicy is set)",
+						},
+					},
+					Required: []string{"imageName"},
+				},
+			},
+			Handler: containerRun,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_run_command",
+				Description: "Runs a command in a new Docker or Podman container with the specified image name, waits for it to finish and returns its exit code, stdout and stderr. The container is removed afterwards, or killed and removed if the command doesn't finish before the timeout. Example: run the tests of a project mounted as a volume",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Run Command",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(false),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"imageName": {
+							Type:        "string",
+							Description: "Docker or Podman container image name to run the command in",
+						},
+						"command": {
+							Type:        "array",
+							Description: "Command and arguments to run. Example: [\"go\", \"test\", \"./...\"]",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"environment": {
+							Type:        "array",
+							Description: "Environment variables to set in the container. Format: <key>=<value>. Example: FOO=bar. (Optional, add only to set environment variables)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"volumes": {
+							Type:        "array",
+							Description: "Bind mounts or named volumes to mount in the container (--volume). Format: [<hostDir|volumeName>:]<containerDir>[:<options>]. Example: /home/user/project:/src:ro. Host directories must be located in the allowed host directories of the server (--allowed-host-dir). (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"network": {
+							Type:        "string",
+							Description: "Network to connect the container to (--network). Example: my-network, host or none. (Optional)",
+						},
+						"entrypoint": {
+							Type:        "array",
+							Description: "Entrypoint to use instead of the image default entrypoint (--entrypoint). Example: [\"/bin/sh\", \"-c\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"workdir": {
+							Type:        "string",
+							Description: "Working directory inside the container (--workdir). (Optional)",
+						},
+						"user": {
+							Type:        "string",
+							Description: "User (name or UID, optionally with group) to run the command as (--user). Example: 1000:1000. (Optional)",
+						},
+						"memory": {
+							Type:        "string",
+							Description: "Memory limit with an optional unit suffix b, k, m or g (--memory). Example: 512m. (Optional)",
+						},
+						"cpus": {
+							Type:        "number",
+							Description: "Number of CPUs the container can use (--cpus). Example: 1.5. (Optional)",
+						},
+						"timeout": {
+							Type:        "integer",
+							Description: "Maximum number of seconds the command can run before the container is killed (Optional, defaults to 300, up to 1800)",
+						},
+					},
+					Required: []string{"imageName", "command"},
+				},
+			},
+			Handler: containerRunCommand,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_start",
+				Description: "Starts a Docker or Podman stopped container with the specified container ID or name",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Start",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to start",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerStart,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_stats",
+				Description: "Displays the resource usage statistics (CPU, memory, network and block IO) of Docker or Podman containers, either as a single snapshot or sampled over a window reporting the min/avg/max of each metric",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Stats",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"names": {
+							Type:        "array",
+							Description: "Docker or Podman container IDs or names to display the statistics. (Optional, defaults to all running containers)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"window": {
+							Type:        "integer",
+							Description: "Number of seconds to sample the statistics over, reporting the min/avg/max of each metric. (Optional, defaults to 0 which returns a single snapshot, maximum 300)",
+						},
+						"interval": {
+							Type:        "integer",
+							Description: "Number of seconds between samples when a window is specified. (Optional, defaults to 1)",
+						},
+					},
+				},
+			},
+			Handler: containerStats,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_stop",
+				Description: "Stops a Docker or Podman running container with the specified container ID or name",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Stop",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to stop",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerStop,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_top",
+				Description: "Displays the running processes of a Docker or Podman container with the specified container ID or name",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Top",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to display the running processes",
+						},
+						"psArgs": {
+							Type:        "array",
+							Description: "Format descriptors or ps(1) options to select the displayed process information. Example: [\"pid\", \"user\", \"args\"] or [\"-ef\"]. (Optional, defaults to user, pid, ppid, %cpu, elapsed, tty, time and command)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerTop,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_unpause",
+				Description: "Resumes all the processes in a Docker or Podman paused container with the specified container ID or name",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Unpause",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to unpause",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerUnpause,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_update",
+				Description: "Updates the resource limits (memory, CPU, pids) and restart policy of an existing Docker or Podman container with the specified container ID or name without recreating it. At least one limit or the restart policy must be specified",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Update",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to update",
+						},
+						"memory": {
+							Type:        "string",
+							Description: "Memory limit with an optional unit suffix (b, k, m, g). Example: 1g. (Optional)",
+						},
+						"memorySwap": {
+							Type:        "string",
+							Description: "Memory plus swap limit with an optional unit suffix (b, k, m, g), -1 for unlimited swap. Example: 2g. (Optional)",
+						},
+						"cpuQuota": {
+							Type:        "integer",
+							Description: "CPU time in microseconds the container can use in each CPU period. Example: 150000 with the default 100000 period for 1.5 CPUs. (Optional)",
+						},
+						"cpuPeriod": {
+							Type:        "integer",
+							Description: "CPU CFS scheduler period in microseconds. (Optional, defaults to the current period)",
+						},
+						"cpuShares": {
+							Type:        "integer",
+							Description: "Relative CPU weight of the container compared to other containers. Example: 512. (Optional)",
+						},
+						"cpusetCpus": {
+							Type:        "string",
+							Description: "CPUs the container is allowed to run on. Example: 0-2,4. (Optional)",
+						},
+						"pidsLimit": {
+							Type:        "integer",
+							Description: "Maximum number of processes of the container, -1 for unlimited. (Optional)",
+						},
+						"restartPolicy": {
+							Type:        "string",
+							Description: "Restart policy: no, always, on-failure[:max-retries] or unless-stopped. Example: on-failure:3. (Optional)",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerUpdate,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "container_wait",
+				Description: "Waits until a Docker or Podman container with the specified container ID or name meets one of the conditions (by default, until it stops) and returns its exit code and final state. Useful to block until a batch job container finishes",
+				Annotations: api.ToolAnnotations{
+					Title:           "Container: Wait",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"name": {
+							Type:        "string",
+							Description: "Docker or Podman container ID or name to wait for",
+						},
+						"conditions": {
+							Type:        "array",
+							Description: "Container states or health statuses to wait for, the wait ends when any of them is met (configured, created, exited, healthy, initialized, paused, removing, running, stopped, stopping, unhealthy). Example: [\"healthy\"]. (Optional, defaults to stopped or exited)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"timeout": {
+							Type:        "integer",
+							Description: "Maximum number of seconds to wait for the conditions (Optional, defaults to 300, up to 1800)",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: containerWait,
+		},
+	}
+}
+
+func containerClone(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	opts := podman.ContainerCloneOptions{
+		Name:   params.GetString("newName", ""),
+		Image:  params.GetString("imageName", ""),
+		Env:    params.GetStringArray("environment"),
+		Memory: params.GetString("memory", ""),
+		CPUs:   params.GetFloat64("cpus", 0),
+		Start:  params.GetBool("start", false),
+	}
+	result, err := params.Podman.ContainerClone(name, opts)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerCommit(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	imageName, err := params.RequiredString("imageName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerCommit(name, imageName,
+		params.GetString("message", ""), params.GetString("author", ""), params.GetStringArray("changes"))
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerCopyFrom(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	containerPath, err := params.RequiredString("containerPath")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	hostPath, err := params.RequiredString("hostPath")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if hostPath, err = params.Config.ResolveHostPath(hostPath); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if err = params.Podman.ContainerCopyFrom(name, containerPath, hostPath); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Copied %s:%s to %s", name, containerPath, hostPath), nil), nil
+}
+
+func containerCopyTo(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	hostPath, err := params.RequiredString("hostPath")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	containerPath, err := params.RequiredString("containerPath")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if hostPath, err = params.Config.ResolveHostPath(hostPath); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if err = params.Podman.ContainerCopyTo(name, hostPath, containerPath); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Copied %s to %s:%s", hostPath, name, containerPath), nil), nil
+}
+
+func containerDiff(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerDiff(name, params.GetString("pathPrefix", ""))
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerExec(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	command, err := params.RequiredStringArray("command")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	env := params.GetStringArray("environment")
+	workdir := params.GetString("workdir", "")
+	user := params.GetString("user", "")
+	result, err := params.Podman.ContainerExec(name, command, env, workdir, user)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return api.NewToolCallResult(formatExecResult(result), nil), nil
+}
+
+func containerFileList(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	filePath, err := params.RequiredString("path")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerFileList(name, filePath)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerFileRead(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	filePath, err := params.RequiredString("path")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	maxSize := int64(params.GetFloat64("maxSize", defaultFileReadMaxSize))
+	if maxSize <= 0 {
+		maxSize = defaultFileReadMaxSize
+	}
+	maxSize = min(maxSize, maxFileReadMaxSize)
+	content, err := params.Podman.ContainerFileRead(name, filePath, maxSize)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if utf8.Valid(content) && !bytes.ContainsRune(content, 0) {
+		return api.NewToolCallResult(string(content), nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Binary file (%d bytes), base64 encoded:\n%s",
+		len(content), base64.StdEncoding.EncodeToString(content)), nil), nil
+}
+
+func containerFileWrite(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	filePath, err := params.RequiredString("path")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	text, err := params.RequiredString("content")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	content := []byte(text)
+	if params.GetBool("base64", false) {
+		if content, err = base64.StdEncoding.DecodeString(text); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("content is not valid base64: %w", err)), nil
+		}
+	}
+	if err = params.Podman.ContainerFileWrite(name, filePath, content); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Wrote %d bytes to %s:%s", len(content), name, filePath), nil), nil
+}
+
+func containerHealth(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	logs := int(params.GetFloat64("logs", defaultHealthLogs))
+	if params.GetBool("run", false) {
+		if _, err = params.Podman.ContainerHealthcheckRun(name); err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+	}
+	result, err := params.Podman.ContainerHealth(name, logs)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerInspect(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerInspect(name)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerKill(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	signal := params.GetString("signal", "")
+	result, err := params.Podman.ContainerKill(name, signal)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerList(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	result, err := params.Podman.ContainerList(podman.ContainerListOptions{
+		All:      params.GetBool("all", true),
+		Status:   params.GetStringArray("status"),
+		Label:    params.GetStringArray("label"),
+		Name:     params.GetStringArray("name"),
+		Ancestor: params.GetStringArray("ancestor"),
+		Network:  params.GetStringArray("network"),
+		Since:    params.GetString("since", ""),
+		Before:   params.GetString("before", ""),
+	})
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerLogs(ctx context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	opts := podman.LogOptions{
+		Tail:       int(params.GetFloat64("tail", 0)),
+		Since:      params.GetString("since", ""),
+		Until:      params.GetString("until", ""),
+		Timestamps: params.GetBool("timestamps", false),
+		Stdout:     params.GetBool("stdout", true),
+		Stderr:     params.GetBool("stderr", true),
+	}
+	if !opts.Stdout && !opts.Stderr {
+		return api.NewToolCallResult("", errors.New("at least one of stdout or stderr must be true")), nil
+	}
+	if params.GetBool("follow", false) {
+		return containerLogsFollow(ctx, params, name, opts)
+	}
+	result, err := params.Podman.ContainerLogs(name, opts)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerLogsFollow(ctx context.Context, params api.ToolHandlerParams, name string, opts podman.LogOptions) (*api.ToolCallResult, error) {
+	var pattern *regexp.Regexp
+	if expr := params.GetString("followPattern", ""); expr != "" {
+		var err error
+		if pattern, err = regexp.Compile(expr); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("followPattern is not a valid regular expression: %w", err)), nil
+		}
+	}
+	duration := time.Duration(params.GetFloat64("followDuration", defaultLogsFollowDuration.Seconds())) * time.Second
+	if duration <= 0 {
+		duration = defaultLogsFollowDuration
+	}
+	duration = min(duration, maxLogsFollowDuration)
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var logs strings.Builder
+	err := params.Podman.ContainerLogsFollow(ctx, name, opts, func(entry string) bool {
+		logs.WriteString(entry)
+		for _, line := range strings.Split(strings.TrimSuffix(entry, "\n"), "\n") {
+			params.Notify(ctx, line)
+			if pattern != nil && pattern.MatchString(line) {
+				return false
+			}
+		}
+		return true
+	})
+	return api.NewToolCallResult(logs.String(), err), nil
+}
+
+func containerPause(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerPause(name)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerPort(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerPort(name)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerPrune(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	result, err := params.Podman.ContainerPrune(pruneFilters(params), !params.GetBool("confirm", false))
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerRemove(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerRemove(name)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerRename(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	newName, err := params.RequiredString("newName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerRename(name, newName)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerRestart(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerRestart(name)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerRun(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	imageName, err := params.RequiredString("imageName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	volumes, err := hostVolumes(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	restartPolicy := params.GetString("restartPolicy", "")
+	opts := podman.ContainerRunOptions{
+		Image:         imageName,
+		Name:          params.GetString("name", ""),
+		Ports:         params.GetPortMappings("ports"),
+		Env:           params.GetStringArray("environment"),
+		Volumes:       volumes,
+		Network:       params.GetString("network", ""),
+		Command:       params.GetStringArray("command"),
+		Entrypoint:    params.GetStringArray("entrypoint"),
+		WorkDir:       params.GetString("workdir", ""),
+		User:          params.GetString("user", ""),
+		Labels:        params.GetStringArray("labels"),
+		Memory:        params.GetString("memory", ""),
+		CPUs:          params.GetFloat64("cpus", 0),
+		RestartPolicy: restartPolicy,
+		// --rm conflicts with restart policies, only default to auto-removal when there's none
+		AutoRemove: params.GetBool("remove", restartPolicy == "" || restartPolicy == "no"),
+	}
+	result, err := params.Podman.ContainerRun(opts)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerRunCommand(ctx context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	imageName, err := params.RequiredString("imageName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	command, err := params.RequiredStringArray("command")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	volumes, err := hostVolumes(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	opts := podman.ContainerRunOptions{
+		Image:      imageName,
+		Env:        params.GetStringArray("environment"),
+		Volumes:    volumes,
+		Network:    params.GetString("network", ""),
+		Command:    command,
+		Entrypoint: params.GetStringArray("entrypoint"),
+		WorkDir:    params.GetString("workdir", ""),
+		User:       params.GetString("user", ""),
+		Memory:     params.GetString("memory", ""),
+		CPUs:       params.GetFloat64("cpus", 0),
+	}
+	result, err := params.Podman.ContainerRunCommand(ctx, opts, waitTimeout(params))
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return api.NewToolCallResult(formatExecResult(result), nil), nil
+}
+
+func containerStart(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerStart(name)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerStats(ctx context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	opts := podman.StatsOptions{
+		Window:   time.Duration(params.GetFloat64("window", 0)) * time.Second,
+		Interval: time.Duration(params.GetFloat64("interval", 1)) * time.Second,
+	}
+	opts.Window = min(opts.Window, maxStatsWindow)
+	result, err := params.Podman.ContainerStats(ctx, params.GetStringArray("names"), opts)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerStop(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerStop(name)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerTop(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerTop(name, params.GetStringArray("psArgs"))
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerUnpause(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerUnpause(name)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerUpdate(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	opts := podman.ContainerUpdateOptions{
+		Memory:        params.GetString("memory", ""),
+		MemorySwap:    params.GetString("memorySwap", ""),
+		CPUQuota:      int64(params.GetFloat64("cpuQuota", 0)),
+		CPUPeriod:     uint64(max(params.GetFloat64("cpuPeriod", 0), 0)),
+		CPUShares:     uint64(max(params.GetFloat64("cpuShares", 0), 0)),
+		CPUSetCPUs:    params.GetString("cpusetCpus", ""),
+		PidsLimit:     int64(params.GetFloat64("pidsLimit", 0)),
+		RestartPolicy: params.GetString("restartPolicy", ""),
+	}
+	if opts == (podman.ContainerUpdateOptions{}) {
+		return api.NewToolCallResult("", errors.New("at least one resource limit or the restart policy must be specified")), nil
+	}
+	result, err := params.Podman.ContainerUpdate(name, opts)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func containerWait(ctx context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := params.RequiredString("name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ContainerWait(ctx, name, params.GetStringArray("conditions"), waitTimeout(params))
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return api.NewToolCallResult(formatWaitResult(name, result), nil), nil
+}
+
+// waitTimeout returns the timeout parameter in seconds as a duration, defaulting to and capped at the wait timeout limits.
+func waitTimeout(params api.ToolHandlerParams) time.Duration {
+	timeout := time.Duration(params.GetFloat64("timeout", defaultWaitTimeout.Seconds())) * time.Second
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	return min(timeout, maxWaitTimeout)
+}
+
+// hostVolumes returns the volumes parameter with the source of the bind mounts resolved to a path in the allowed
+// host directories, rejecting bind mounts of any other host path. Named and anonymous volumes are returned unchanged.
+func hostVolumes(params api.ToolHandlerParams) ([]string, error) {
+	volumes := params.GetStringArray("volumes")
+	for i, volume := range volumes {
+		source, rest, found := strings.Cut(volume, ":")
+		// podman mounts sources starting with / or . as host paths, any other source is a volume name
+		if !found || (!strings.HasPrefix(source, "/") && !strings.HasPrefix(source, ".")) {
+			continue
+		}
+		hostPath, err := params.Config.ResolveHostPath(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid volume %s: %w", volume, err)
+		}
+		volumes[i] = hostPath + ":" + rest
+	}
+	return volumes, nil
+}
+
+// pruneFilters returns the podman prune filters (label, label! and until) of the parameters.
+// The key!=value labels exclude the resources, podman expects them as key=value in the label! filter.
+func pruneFilters(params api.ToolHandlerParams) map[string][]string {
+	filters := map[string][]string{}
+	for _, label := range params.GetStringArray("label") {
+		if key, value, negated := strings.Cut(label, "!="); negated {
+			filters["label!"] = append(filters["label!"], key+"="+value)
+		} else {
+			filters["label"] = append(filters["label"], label)
+		}
+	}
+	if until := params.GetString("until", ""); until != "" {
+		filters["until"] = []string{until}
+	}
+	return filters
+}
+
+// formatExecResult renders the exit code, stdout and stderr of an executed command.
+func formatExecResult(result *podman.ExecResult) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Exit code: %d\n", result.ExitCode))
+	sb.WriteString("Stdout:\n")
+	sb.WriteString(result.Stdout)
+	if result.Stdout != "" && !strings.HasSuffix(result.Stdout, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Stderr:\n")
+	sb.WriteString(result.Stderr)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// formatWaitResult renders the final state and exit code of a waited container.
+func formatWaitResult(name string, result *podman.WaitResult) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Container %s met the wait condition\n", name))
+	if result.State != "" {
+		sb.WriteString(fmt.Sprintf("State: %s\n", result.State))
+	}
+	if result.ExitCode >= 0 {
+		sb.WriteString(fmt.Sprintf("Exit code: %d\n", result.ExitCode))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
 }
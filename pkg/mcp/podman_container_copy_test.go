@@ -0,0 +1,272 @@
+package mcp_test
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/manusa/podman-mcp-server/internal/test"
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// ContainerCopySuite tests the container copy tools with an allowed host directory configured.
+type ContainerCopySuite struct {
+	test.McpSuite
+}
+
+func TestContainerCopySuiteWithAllImplementations(t *testing.T) {
+	for _, impl := range test.AvailableImplementations() {
+		t.Run(impl, func(t *testing.T) {
+			suite.Run(t, &ContainerCopySuite{
+				McpSuite: test.McpSuite{Config: config.Config{
+					PodmanImpl:      impl,
+					AllowedHostDirs: []string{t.TempDir()},
+				}},
+			})
+		})
+	}
+}
+
+// allowedDir returns the (symlink resolved) allowed host directory.
+func (s *ContainerCopySuite) allowedDir() string {
+	dir, err := filepath.EvalSymlinks(s.Config.AllowedHostDirs[0])
+	s.Require().NoError(err)
+	return dir
+}
+
+// archiveFiles returns the name and content of the files in the tar archive.
+func archiveFiles(t *testing.T, archive string) map[string]string {
+	files := make(map[string]string)
+	tr := tar.NewReader(strings.NewReader(archive))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+}
+
+func (s *ContainerCopySuite) TestContainerCopyFrom() {
+	s.WithContainerArchive(map[string]string{
+		"/etc/nginx/nginx.conf":      "worker_processes 1;",
+		"/etc/nginx/conf.d/app.conf": "server {}",
+	})
+
+	s.Run("container_copy_from(hostPath=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_copy_from", map[string]interface{}{
+			"name":          "web",
+			"containerPath": "/etc/nginx/nginx.conf",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "hostPath", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_copy_from(file) copies the file to the host path", func() {
+		hostPath := filepath.Join(s.allowedDir(), "copied.conf")
+
+		toolResult, err := s.CallTool("container_copy_from", map[string]interface{}{
+			"name":          "web",
+			"containerPath": "/etc/nginx/nginx.conf",
+			"hostPath":      hostPath,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns copy message", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Copied web:/etc/nginx/nginx.conf to "+hostPath, text)
+		})
+
+		s.Run("writes file content", func() {
+			content, err := os.ReadFile(hostPath)
+			s.Require().NoError(err)
+			s.Equal("worker_processes 1;", string(content))
+		})
+	})
+
+	s.Run("container_copy_from(directory) copies the directory into the existing host directory", func() {
+		hostDir := filepath.Join(s.allowedDir(), "existing")
+		s.Require().NoError(os.Mkdir(hostDir, 0o755))
+
+		toolResult, err := s.CallTool("container_copy_from", map[string]interface{}{
+			"name":          "web",
+			"containerPath": "/etc/nginx",
+			"hostPath":      hostDir,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("writes directory files", func() {
+			content, err := os.ReadFile(filepath.Join(hostDir, "nginx", "nginx.conf"))
+			s.Require().NoError(err)
+			s.Equal("worker_processes 1;", string(content))
+			content, err = os.ReadFile(filepath.Join(hostDir, "nginx", "conf.d", "app.conf"))
+			s.Require().NoError(err)
+			s.Equal("server {}", string(content))
+		})
+	})
+
+	s.Run("container_copy_from(hostPath outside allowed dirs) returns error", func() {
+		s.MockServer.ClearRequests()
+		hostPath := filepath.Join(s.T().TempDir(), "nginx.conf")
+
+		toolResult, err := s.CallTool("container_copy_from", map[string]interface{}{
+			"name":          "web",
+			"containerPath": "/etc/nginx/nginx.conf",
+			"hostPath":      hostPath,
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "is not located in any of the allowed host directories")
+		})
+
+		s.Run("does not copy the file", func() {
+			s.False(s.MockServer.HasRequest("GET", "/libpod/containers/web/archive"))
+			s.NoFileExists(hostPath)
+		})
+	})
+
+	s.Run("container_copy_from(hostPath traversing out of allowed dir) returns error", func() {
+		toolResult, err := s.CallTool("container_copy_from", map[string]interface{}{
+			"name":          "web",
+			"containerPath": "/etc/nginx/nginx.conf",
+			"hostPath":      s.allowedDir() + "/../nginx.conf",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "is not located in any of the allowed host directories")
+	})
+}
+
+func (s *ContainerCopySuite) TestContainerCopyTo() {
+	s.WithContainerArchive(map[string]string{
+		"/etc/nginx/nginx.conf": "worker_processes 1;",
+	})
+	hostPath := filepath.Join(s.allowedDir(), "app.conf")
+	s.Require().NoError(os.WriteFile(hostPath, []byte("server {}"), 0o644))
+
+	s.Run("container_copy_to(containerPath=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_copy_to", map[string]interface{}{
+			"name":     "web",
+			"hostPath": hostPath,
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "containerPath", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_copy_to(existing directory) copies the file into the directory", func() {
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("container_copy_to", map[string]interface{}{
+			"name":          "web",
+			"hostPath":      hostPath,
+			"containerPath": "/etc/nginx",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns copy message", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Copied "+hostPath+" to web:/etc/nginx", text)
+		})
+
+		s.Run("uploads archive to the directory", func() {
+			req := s.MockServer.GetRequest("PUT", "/libpod/containers/web/archive")
+			s.Require().NotNil(req, "archive PUT request should be made")
+			s.Contains(req.Query, "path=%2Fetc%2Fnginx")
+			s.Equal(map[string]string{"app.conf": "server {}"}, archiveFiles(s.T(), req.Body))
+		})
+	})
+
+	s.Run("container_copy_to(new path) copies the file as the path", func() {
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("container_copy_to", map[string]interface{}{
+			"name":          "web",
+			"hostPath":      hostPath,
+			"containerPath": "/etc/nginx/default.conf",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("uploads renamed archive to the parent directory", func() {
+			req := s.MockServer.GetRequest("PUT", "/libpod/containers/web/archive")
+			s.Require().NotNil(req, "archive PUT request should be made")
+			s.Contains(req.Query, "path=%2Fetc%2Fnginx")
+			s.Equal(map[string]string{"default.conf": "server {}"}, archiveFiles(s.T(), req.Body))
+		})
+	})
+
+	s.Run("container_copy_to(hostPath outside allowed dirs) returns error", func() {
+		s.MockServer.ClearRequests()
+		outside := filepath.Join(s.T().TempDir(), "secret")
+		s.Require().NoError(os.WriteFile(outside, []byte("secret"), 0o600))
+
+		toolResult, err := s.CallTool("container_copy_to", map[string]interface{}{
+			"name":          "web",
+			"hostPath":      outside,
+			"containerPath": "/etc/nginx",
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "is not located in any of the allowed host directories")
+		})
+
+		s.Run("does not upload the file", func() {
+			s.False(s.MockServer.HasRequest("PUT", "/libpod/containers/web/archive"))
+		})
+	})
+
+	s.Run("container_copy_to(hostPath symlink out of allowed dir) returns error", func() {
+		outside := filepath.Join(s.T().TempDir(), "secret")
+		s.Require().NoError(os.WriteFile(outside, []byte("secret"), 0o600))
+		link := filepath.Join(s.allowedDir(), "link")
+		s.Require().NoError(os.Symlink(outside, link))
+
+		toolResult, err := s.CallTool("container_copy_to", map[string]interface{}{
+			"name":          "web",
+			"hostPath":      link,
+			"containerPath": "/etc/nginx",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "is not located in any of the allowed host directories")
+	})
+}
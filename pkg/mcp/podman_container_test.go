@@ -1,11 +1,16 @@
 package mcp_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/suite"
@@ -206,6 +211,209 @@ func (s *ContainerSuite) TestContainerListEmpty() {
 	})
 }
 
+func (s *ContainerSuite) TestContainerListFilters() {
+	s.WithContainerList([]test.ContainerListResponse{})
+
+	// listQuery returns the all flag and the filters of the last container list request
+	listQuery := func() (string, map[string][]string) {
+		req := s.MockServer.GetRequest("GET", "/libpod/containers/json")
+		s.Require().NotNil(req, "container list request should be made")
+		query, err := url.ParseQuery(req.Query)
+		s.Require().NoError(err)
+		var filters map[string][]string
+		if raw := query.Get("filters"); raw != "" {
+			s.Require().NoError(json.Unmarshal([]byte(raw), &filters))
+		}
+		return query.Get("all"), filters
+	}
+
+	s.Run("container_list() lists all containers without filters", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("container_list", map[string]interface{}{})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		all, filters := listQuery()
+		s.Equal("true", all)
+		s.Empty(filters)
+	})
+
+	s.Run("container_list(all=false) lists running containers", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("container_list", map[string]interface{}{
+			"all": false,
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		all, _ := listQuery()
+		s.NotEqual("true", all)
+	})
+
+	s.Run("container_list(filters) sends the filters", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("container_list", map[string]interface{}{
+			"status":   []interface{}{"running", "paused"},
+			"label":    []interface{}{"app=web"},
+			"name":     []interface{}{"^web-"},
+			"ancestor": []interface{}{"nginx:latest"},
+			"network":  []interface{}{"frontend"},
+			"since":    "db",
+			"before":   "cache",
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		_, filters := listQuery()
+		s.Equal(map[string][]string{
+			"status":   {"running", "paused"},
+			"label":    {"app=web"},
+			"name":     {"^web-"},
+			"ancestor": {"nginx:latest"},
+			"network":  {"frontend"},
+			"since":    {"db"},
+			"before":   {"cache"},
+		}, filters)
+	})
+
+	s.Run("container_list(label=[app=web, tier=frontend]) lists only the containers with all the labels", func() {
+		s.WithContainerList([]test.ContainerListResponse{
+			{ID: "abc123def456", Names: []string{"web-frontend"}, Image: "nginx:latest", State: "running", Created: "2024-01-01T00:00:00Z",
+				Labels: map[string]string{"app": "web", "tier": "frontend"}},
+			{ID: "def456abc123", Names: []string{"web-backend"}, Image: "nginx:latest", State: "running", Created: "2024-01-01T00:00:00Z",
+				Labels: map[string]string{"app": "web", "tier": "backend"}},
+			{ID: "fed654cba321", Names: []string{"docs-frontend"}, Image: "nginx:latest", State: "running", Created: "2024-01-01T00:00:00Z",
+				Labels: map[string]string{"app": "docs", "tier": "frontend"}},
+		})
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("container_list", map[string]interface{}{
+			"label": []interface{}{"app=web", "tier=frontend"},
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("sends all the labels in the label filter", func() {
+			_, filters := listQuery()
+			s.Equal(map[string][]string{"label": {"app=web", "tier=frontend"}}, filters)
+		})
+
+		s.Run("returns only the container with all the labels", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "web-frontend")
+			s.NotContains(text, "web-backend")
+			s.NotContains(text, "docs-frontend")
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerHealth() {
+	healthyContainer := test.ContainerInspectResponse{
+		ID:      "abc123def456",
+		Name:    "/test-container",
+		Created: "2024-01-01T00:00:00Z",
+		State: &test.ContainerState{
+			Status:  "running",
+			Running: true,
+			Health: &test.HealthState{
+				Status:        "unhealthy",
+				FailingStreak: 2,
+				Log: []test.HealthLog{
+					{Start: "2024-01-01T00:00:00Z", End: "2024-01-01T00:00:01Z", ExitCode: 0, Output: "probe 1 ok"},
+					{Start: "2024-01-01T00:00:30Z", End: "2024-01-01T00:00:31Z", ExitCode: 1, Output: "probe 2 failed"},
+					{Start: "2024-01-01T00:01:00Z", End: "2024-01-01T00:01:01Z", ExitCode: 1, Output: "probe 3 failed"},
+				},
+			},
+		},
+	}
+
+	s.Run("container_health(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_health", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_health(name=test-container) returns health report", func() {
+		s.WithContainerInspect(healthyContainer)
+
+		toolResult, err := s.CallTool("container_health", map[string]interface{}{
+			"name": "test-container",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+
+		s.Run("returns status and failing streak", func() {
+			s.Contains(text, "Status: unhealthy")
+			s.Contains(text, "Failing streak: 2")
+		})
+
+		s.Run("returns probe outputs", func() {
+			s.Contains(text, "probe 1 ok")
+			s.Contains(text, "exit code 1:\nprobe 3 failed")
+		})
+	})
+
+	s.Run("container_health(name=test-container, logs=1) returns the last probe output", func() {
+		s.WithContainerInspect(healthyContainer)
+
+		toolResult, err := s.CallTool("container_health", map[string]interface{}{
+			"name": "test-container",
+			"logs": 1,
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "probe 3 failed")
+		s.NotContains(text, "probe 2 failed")
+		s.NotContains(text, "probe 1 ok")
+	})
+
+	s.Run("container_health(name=test-container, run=true) runs the healthcheck", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerInspect(healthyContainer)
+		s.WithContainerHealthcheck("unhealthy")
+
+		toolResult, err := s.CallTool("container_health", map[string]interface{}{
+			"name": "test-container",
+			"run":  true,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("runs healthcheck", func() {
+			s.True(s.MockServer.HasRequest("GET", "/libpod/containers/test-container/healthcheck"))
+		})
+	})
+
+	s.Run("container_health(name=test-container) without healthcheck returns error", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/test-container",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "running", Running: true},
+		})
+
+		toolResult, err := s.CallTool("container_health", map[string]interface{}{
+			"name": "test-container",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "has no healthcheck defined")
+	})
+}
+
 func (s *ContainerSuite) TestContainerInspect() {
 	s.Run("container_inspect(name=nil) returns error", func() {
 		toolResult, err := s.CallTool("container_inspect", map[string]interface{}{})
@@ -216,51 +424,1699 @@ func (s *ContainerSuite) TestContainerInspect() {
 		s.Contains(text, "required", "error should indicate parameter is required")
 	})
 
-	s.Run("container_inspect(name=123) returns error for non-string parameter", func() {
-		toolResult, err := s.CallTool("container_inspect", map[string]interface{}{
-			"name": 123,
+	s.Run("container_inspect(name=123) returns error for non-string parameter", func() {
+		toolResult, err := s.CallTool("container_inspect", map[string]interface{}{
+			"name": 123,
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the parameter")
+		s.Contains(text, "must be a string", "error should indicate parameter must be a string")
+	})
+
+	s.Run("container_inspect(name=nonexistent) returns not found error", func() {
+		s.WithError("GET", "/libpod/containers/{id}/json", "/containers/{id}/json",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_inspect", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotEmpty(text, "error message should not be empty")
+	})
+
+	s.Run("container_inspect(name=test-container) returns container details", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:        "abc123def456",
+			Name:      "/test-container",
+			Image:     "sha256:abc123",
+			ImageName: "docker.io/library/nginx:latest",
+			Created:   "2024-01-01T00:00:00Z",
+			State: &test.ContainerState{
+				Status:    "running",
+				Running:   true,
+				StartedAt: "2024-01-01T00:00:00Z",
+			},
+			Config: &test.ContainerConfig{
+				Image:    "docker.io/library/nginx:latest",
+				Hostname: "abc123def456",
+				Env:      []string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"},
+			},
+		})
+
+		toolResult, err := s.CallTool("container_inspect", map[string]interface{}{
+			"name": "test-container",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns container details with expected format", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "abc123def456", "should contain container ID")
+			s.Contains(text, "nginx", "should contain image name")
+			s.Contains(text, "running", "should contain container state")
+		})
+
+		s.Run("mock server received inspect request", func() {
+			s.True(s.MockServer.HasRequest("GET", "/libpod/containers/{id}/json"))
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerClone() {
+	s.Run("container_clone(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_clone", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_clone(name=web, changes) creates a modified copy of the container", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:        "abc123def456",
+			Name:      "/web",
+			Image:     "sha256:abc123",
+			ImageName: "docker.io/library/nginx:latest",
+			Created:   "2024-01-01T00:00:00Z",
+			State:     &test.ContainerState{Status: "exited"},
+			Config: &test.ContainerConfig{
+				Cmd:        []string{"nginx", "-g", "daemon off;"},
+				Entrypoint: []string{"/docker-entrypoint.sh"},
+				Env:        []string{"PATH=/usr/bin:/bin", "HOSTNAME=abc123def456", "FOO=bar"},
+				Labels:     map[string]string{"app": "web"},
+			},
+			HostConfig: &test.HostConfig{
+				Binds:         []string{"/srv/www:/usr/share/nginx/html:ro"},
+				PortBindings:  map[string][]test.PortBinding{"80/tcp": {{HostPort: "8080"}}},
+				Memory:        536870912,
+				RestartPolicy: &test.RestartPolicy{Name: "always"},
+			},
+		})
+		s.WithContainerRun("def456abc123")
+
+		toolResult, err := s.CallTool("container_clone", map[string]interface{}{
+			"name":        "web",
+			"newName":     "web-v2",
+			"environment": []interface{}{"FOO=baz"},
+			"cpus":        1.5,
+			"start":       true,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns new container ID", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "def456abc123")
+		})
+
+		req := s.MockServer.GetRequest("POST", "/libpod/containers/create")
+		s.Require().NotNil(req, "create request should be made")
+
+		s.Run("create request includes new settings", func() {
+			s.Contains(req.Body, `"name":"web-v2"`)
+			s.Contains(req.Body, `"FOO":"baz"`)
+			s.Contains(req.Body, `"quota":150000`)
+		})
+
+		s.Run("create request copies the container settings", func() {
+			s.Contains(req.Body, `"image":"docker.io/library/nginx:latest"`)
+			s.Contains(req.Body, `"command":["nginx","-g","daemon off;"]`)
+			s.Contains(req.Body, `"entrypoint":["/docker-entrypoint.sh"]`)
+			s.Contains(req.Body, `"app":"web"`)
+			s.Contains(req.Body, `"source":"/srv/www"`)
+			s.Contains(req.Body, `"host_port":8080`)
+			s.Contains(req.Body, `"limit":536870912`)
+			s.Contains(req.Body, `"restart_policy":"always"`)
+		})
+
+		s.Run("create request doesn't copy the hostname", func() {
+			s.NotContains(req.Body, "HOSTNAME")
+		})
+
+		s.Run("starts the new container", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/def456abc123/start"))
+		})
+	})
+
+	s.Run("container_clone(name=dns) copies the port bindings with their protocol and host IP", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:        "abc123def456",
+			Name:      "/dns",
+			Image:     "sha256:abc123",
+			ImageName: "docker.io/coredns/coredns:latest",
+			Created:   "2024-01-01T00:00:00Z",
+			State:     &test.ContainerState{Status: "running"},
+			Config:    &test.ContainerConfig{},
+			HostConfig: &test.HostConfig{
+				PortBindings: map[string][]test.PortBinding{
+					"53/tcp":   {{HostPort: "53"}},
+					"53/udp":   {{HostPort: "53"}},
+					"9153/tcp": {{HostIP: "127.0.0.1", HostPort: "9153"}},
+				},
+			},
+		})
+		s.WithContainerRun("def456abc123")
+
+		toolResult, err := s.CallTool("container_clone", map[string]interface{}{
+			"name": "dns",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("create request publishes all the port bindings", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/containers/create")
+			s.Require().NotNil(req, "create request should be made")
+			var spec struct {
+				PortMappings []struct {
+					HostIP        string `json:"host_ip"`
+					HostPort      int    `json:"host_port"`
+					ContainerPort int    `json:"container_port"`
+					Protocol      string `json:"protocol"`
+				} `json:"portmappings"`
+			}
+			s.Require().NoError(json.Unmarshal([]byte(req.Body), &spec))
+			mappings := make([]string, 0, len(spec.PortMappings))
+			for _, m := range spec.PortMappings {
+				mappings = append(mappings, fmt.Sprintf("%s:%d:%d/%s", m.HostIP, m.HostPort, m.ContainerPort, m.Protocol))
+			}
+			s.ElementsMatch([]string{":53:53/tcp", ":53:53/udp", "127.0.0.1:9153:9153/tcp"}, mappings)
+		})
+	})
+
+	s.Run("container_clone(name=web) with an invalid port binding returns error", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:        "abc123def456",
+			Name:      "/web",
+			Image:     "sha256:abc123",
+			ImageName: "docker.io/library/nginx:latest",
+			Created:   "2024-01-01T00:00:00Z",
+			State:     &test.ContainerState{Status: "running"},
+			Config:    &test.ContainerConfig{},
+			HostConfig: &test.HostConfig{
+				PortBindings: map[string][]test.PortBinding{"80/tcp": {{HostPort: "http"}}},
+			},
+		})
+
+		toolResult, err := s.CallTool("container_clone", map[string]interface{}{
+			"name": "web",
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "failed to clone the port bindings of the container: invalid host port http")
+		})
+
+		s.Run("does not create the container", func() {
+			s.False(s.MockServer.HasRequest("POST", "/libpod/containers/create"))
+		})
+	})
+
+	s.Run("container_clone(name=nonexistent) returns not found error", func() {
+		s.WithError("GET", "/libpod/containers/{id}/json", "/containers/{id}/json",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_clone", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
+func (s *ContainerSuite) TestContainerCommit() {
+	const imageID = "8f2e1b3c4d5a6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f7"
+
+	s.Run("container_commit(imageName=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_commit", map[string]interface{}{
+			"name": "web",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "imageName", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_commit(name=web, imageName=my-app:snapshot) commits the container", func() {
+		s.WithContainerCommit(imageID)
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("container_commit", map[string]interface{}{
+			"name":      "web",
+			"imageName": "my-app:snapshot",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns image ID", func() {
+			s.Equal(imageID, toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+
+		s.Run("commits container as repository and tag", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/commit")
+			s.Require().NotNil(req, "commit request should be made")
+			s.Contains(req.Query, "container=web")
+			s.Contains(req.Query, "repo=my-app")
+			s.Contains(req.Query, "tag=snapshot")
+		})
+	})
+
+	s.Run("container_commit(message, author, changes) sets the image metadata", func() {
+		s.WithContainerCommit(imageID)
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("container_commit", map[string]interface{}{
+			"name":      "web",
+			"imageName": "my-app",
+			"message":   "Configured app",
+			"author":    "Jane Doe",
+			"changes":   []interface{}{"ENV APP_ENV=production", "CMD npm start"},
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("sends metadata and changes", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/commit")
+			s.Require().NotNil(req, "commit request should be made")
+			s.Contains(req.Query, "comment=Configured+app")
+			s.Contains(req.Query, "author=Jane+Doe")
+			s.Contains(req.Query, "format=docker")
+			s.Contains(req.Query, "changes=ENV+APP_ENV%3Dproduction")
+			s.Contains(req.Query, "changes=CMD+npm+start")
+		})
+	})
+
+	s.Run("container_commit(name=missing) returns error", func() {
+		s.WithError("POST", "/libpod/commit", "/commit", http.StatusNotFound, "no such container missing")
+
+		toolResult, err := s.CallTool("container_commit", map[string]interface{}{
+			"name":      "missing",
+			"imageName": "my-app",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "no such container")
+	})
+}
+
+func (s *ContainerSuite) TestContainerCopyWithoutAllowedHostDirs() {
+	s.WithContainerArchive(map[string]string{
+		"/etc/nginx/nginx.conf": "worker_processes 1;",
+	})
+
+	for _, tool := range []string{"container_copy_from", "container_copy_to"} {
+		s.Run(tool+"() returns host access disabled error", func() {
+			toolResult, err := s.CallTool(tool, map[string]interface{}{
+				"name":          "web",
+				"containerPath": "/etc/nginx/nginx.conf",
+				"hostPath":      "/tmp/nginx.conf",
+			})
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "access to the host filesystem is disabled")
+			s.False(s.MockServer.HasRequest("GET", "/libpod/containers/web/archive"))
+			s.False(s.MockServer.HasRequest("PUT", "/libpod/containers/web/archive"))
+		})
+	}
+}
+
+func (s *ContainerSuite) TestContainerDiff() {
+	s.Run("container_diff(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_diff", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_diff(name=web) returns all changes sorted by path", func() {
+		s.WithContainerDiff([]test.ContainerChange{
+			{Path: "/etc", Kind: 0},
+			{Path: "/etc/nginx/conf.d/default.conf", Kind: 0},
+			{Path: "/etc/nginx/conf.d/app.conf", Kind: 1},
+			{Path: "/etcetera", Kind: 1},
+			{Path: "/tmp/cache", Kind: 2},
+		})
+
+		toolResult, err := s.CallTool("container_diff", map[string]interface{}{
+			"name": "web",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns changes", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("C /etc\n"+
+				"A /etc/nginx/conf.d/app.conf\n"+
+				"C /etc/nginx/conf.d/default.conf\n"+
+				"A /etcetera\n"+
+				"D /tmp/cache", text)
+		})
+	})
+
+	s.Run("container_diff(name=web, pathPrefix=/etc/) returns changes under the path", func() {
+		s.WithContainerDiff([]test.ContainerChange{
+			{Path: "/etc", Kind: 0},
+			{Path: "/etc/nginx/conf.d/default.conf", Kind: 0},
+			{Path: "/etc/nginx/conf.d/app.conf", Kind: 1},
+			{Path: "/etcetera", Kind: 1},
+			{Path: "/tmp/cache", Kind: 2},
+		})
+
+		toolResult, err := s.CallTool("container_diff", map[string]interface{}{
+			"name":       "web",
+			"pathPrefix": "/etc/",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns filtered changes", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("C /etc\n"+
+				"A /etc/nginx/conf.d/app.conf\n"+
+				"C /etc/nginx/conf.d/default.conf", text)
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerExec() {
+	s.Run("container_exec(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_exec", map[string]interface{}{
+			"command": []interface{}{"ls"},
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_exec(command=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_exec", map[string]interface{}{
+			"name": "test-container",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "command", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_exec(command=[]) returns error", func() {
+		toolResult, err := s.CallTool("container_exec", map[string]interface{}{
+			"name":    "test-container",
+			"command": []interface{}{},
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "must not be empty", "error should indicate command must not be empty")
+	})
+
+	s.Run("container_exec(name=nonexistent) returns not found error", func() {
+		s.WithError("POST", "/libpod/containers/{id}/exec", "/containers/{id}/exec",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_exec", map[string]interface{}{
+			"name":    "nonexistent",
+			"command": []interface{}{"ls"},
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotEmpty(text, "error message should not be empty")
+	})
+
+	s.Run("container_exec(name=test-container, command=[...]) executes command", func() {
+		s.WithContainerExec("NAME=\"Alpine Linux\"\n", "warning: something\n", 3)
+
+		toolResult, err := s.CallTool("container_exec", map[string]interface{}{
+			"name":        "test-container",
+			"command":     []interface{}{"cat", "/etc/os-release"},
+			"environment": []interface{}{"FOO=BAR"},
+			"workdir":     "/tmp",
+			"user":        "1000:1000",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns exit code", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "Exit code: 3")
+		})
+
+		s.Run("returns stdout and stderr separately", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Regexp(`(?s)Stdout:\nNAME="Alpine Linux"\nStderr:\nwarning: something`, text)
+		})
+
+		s.Run("exec create request includes command and options", func() {
+			req := s.PopLastCapturedRequest("POST", "/libpod/containers/{id}/exec")
+			s.Require().NotNil(req, "exec create request should be captured")
+			s.Contains(req.Body, `"cat"`, "should contain the command")
+			s.Contains(req.Body, `"/etc/os-release"`, "should contain the command arguments")
+			s.Contains(req.Body, `"FOO=BAR"`, "should contain the environment variable")
+			s.Contains(req.Body, `"/tmp"`, "should contain the working directory")
+			s.Contains(req.Body, `"1000:1000"`, "should contain the user")
+		})
+
+		s.Run("mock server received exec start request", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/exec/{id}/start"))
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerFileList() {
+	s.WithContainerArchive(map[string]string{
+		"/etc/nginx/nginx.conf":      "worker_processes 1;",
+		"/etc/nginx/conf.d/app.conf": "server {}",
+	})
+
+	s.Run("container_file_list(path=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_file_list", map[string]interface{}{
+			"name": "web",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "path", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_file_list(path=/etc/nginx) lists the directory entries", func() {
+		toolResult, err := s.CallTool("container_file_list", map[string]interface{}{
+			"name": "web",
+			"path": "/etc/nginx",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		lines := strings.Split(text, "\n")
+
+		s.Run("returns header and direct children only", func() {
+			s.Require().Len(lines, 3)
+			s.Regexp(`^MODE\s+SIZE\s+MODIFIED\s+NAME$`, lines[0])
+		})
+
+		s.Run("returns directory with mode", func() {
+			s.Regexp(`^drwxr-xr-x\s+0\s+2024-01-01T00:00:00Z\s+conf\.d$`, lines[1])
+		})
+
+		s.Run("returns file with mode and size", func() {
+			s.Regexp(`^-rw-r--r--\s+19\s+2024-01-01T00:00:00Z\s+nginx\.conf$`, lines[2])
+		})
+	})
+
+	s.Run("container_file_list(path=/etc/nginx/nginx.conf) lists the file", func() {
+		toolResult, err := s.CallTool("container_file_list", map[string]interface{}{
+			"name": "web",
+			"path": "/etc/nginx/nginx.conf",
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		lines := strings.Split(toolResult.Content[0].(*mcp.TextContent).Text, "\n")
+		s.Require().Len(lines, 2)
+		s.Regexp(`^-rw-r--r--\s+19\s+2024-01-01T00:00:00Z\s+nginx\.conf$`, lines[1])
+	})
+
+	s.Run("container_file_list(path=/missing) returns error", func() {
+		toolResult, err := s.CallTool("container_file_list", map[string]interface{}{
+			"name": "web",
+			"path": "/missing",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
+func (s *ContainerSuite) TestContainerFileRead() {
+	s.WithContainerArchive(map[string]string{
+		"/etc/nginx/nginx.conf": "worker_processes 1;",
+		"/usr/bin/app":          "\x7fELF\x00\x01",
+	})
+
+	s.Run("container_file_read(path=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_file_read", map[string]interface{}{
+			"name": "web",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "path", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_file_read(text file) returns the content", func() {
+		toolResult, err := s.CallTool("container_file_read", map[string]interface{}{
+			"name": "web",
+			"path": "/etc/nginx/nginx.conf",
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		s.Equal("worker_processes 1;", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+
+	s.Run("container_file_read(binary file) returns the base64 encoded content", func() {
+		toolResult, err := s.CallTool("container_file_read", map[string]interface{}{
+			"name": "web",
+			"path": "/usr/bin/app",
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		s.Equal("Binary file (6 bytes), base64 encoded:\nf0VMRgAB", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+
+	s.Run("container_file_read(maxSize=10) returns error for larger file", func() {
+		toolResult, err := s.CallTool("container_file_read", map[string]interface{}{
+			"name":    "web",
+			"path":    "/etc/nginx/nginx.conf",
+			"maxSize": 10,
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "exceeds the maximum readable size of 10 bytes")
+	})
+
+	s.Run("container_file_read(directory) returns error", func() {
+		toolResult, err := s.CallTool("container_file_read", map[string]interface{}{
+			"name": "web",
+			"path": "/etc/nginx",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "/etc/nginx is a directory")
+	})
+}
+
+func (s *ContainerSuite) TestContainerFileWrite() {
+	s.WithContainerArchive(map[string]string{
+		"/etc/nginx/nginx.conf": "worker_processes 1;",
+	})
+
+	s.Run("container_file_write(content=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_file_write", map[string]interface{}{
+			"name": "web",
+			"path": "/etc/nginx/conf.d/app.conf",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "content", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_file_write(text content) uploads the file", func() {
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("container_file_write", map[string]interface{}{
+			"name":    "web",
+			"path":    "/etc/nginx/default.conf",
+			"content": "server {}",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns write message", func() {
+			s.Equal("Wrote 9 bytes to web:/etc/nginx/default.conf", toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+
+		s.Run("uploads archive with the file to the parent directory", func() {
+			req := s.MockServer.GetRequest("PUT", "/libpod/containers/web/archive")
+			s.Require().NotNil(req, "archive PUT request should be made")
+			s.Contains(req.Query, "path=%2Fetc%2Fnginx")
+			s.Equal(map[string]string{"default.conf": "server {}"}, archiveFiles(s.T(), req.Body))
+		})
+	})
+
+	s.Run("container_file_write(base64=true) uploads the decoded content", func() {
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("container_file_write", map[string]interface{}{
+			"name":    "web",
+			"path":    "/etc/nginx/app",
+			"content": "f0VMRgAB",
+			"base64":  true,
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		req := s.MockServer.GetRequest("PUT", "/libpod/containers/web/archive")
+		s.Require().NotNil(req, "archive PUT request should be made")
+		s.Equal(map[string]string{"app": "\x7fELF\x00\x01"}, archiveFiles(s.T(), req.Body))
+	})
+
+	s.Run("container_file_write(invalid base64) returns error", func() {
+		toolResult, err := s.CallTool("container_file_write", map[string]interface{}{
+			"name":    "web",
+			"path":    "/etc/nginx/app",
+			"content": "not base64!",
+			"base64":  true,
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "content is not valid base64")
+	})
+}
+
+func (s *ContainerSuite) TestContainerStop() {
+	s.Run("container_stop(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_stop", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_stop(name=nonexistent) returns not found error", func() {
+		s.WithError("GET", "/libpod/containers/{id}/json", "/containers/{id}/json",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_stop", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotEmpty(text, "error message should not be empty")
+	})
+
+	s.Run("container_stop(name=test-container) stops container", func() {
+		// Podman first looks up container by name, then inspects, then stops
+		s.WithContainerList([]test.ContainerListResponse{
+			{
+				ID:        "abc123def456",
+				Names:     []string{"test-container"},
+				Image:     "docker.io/library/nginx:latest",
+				State:     "running",
+				Created:   "2024-01-01T00:00:00Z",
+				Command:   []string{"/bin/sh"},
+				StartedAt: 1704067200,
+			},
+		})
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/test-container",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "running", Running: true},
+		})
+		s.WithContainerStop()
+
+		toolResult, err := s.CallTool("container_stop", map[string]interface{}{
+			"name": "test-container",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns success response with container name", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "test-container", "should contain the stopped container name")
+		})
+
+		s.Run("mock server received stop request", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/{id}/stop"))
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerStart() {
+	s.Run("container_start(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_start", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_start(name=nonexistent) returns not found error", func() {
+		s.WithError("POST", "/libpod/containers/{id}/start", "/containers/{id}/start",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_start", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotEmpty(text, "error message should not be empty")
+	})
+
+	s.Run("container_start(name=test-container) starts container", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/test-container",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "exited", Running: false},
+		})
+		s.WithContainerStart()
+
+		toolResult, err := s.CallTool("container_start", map[string]interface{}{
+			"name": "test-container",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns success response with container name", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.NotEmpty(text, "should return the container name or ID")
+		})
+
+		s.Run("mock server received start request", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/{id}/start"))
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerRestart() {
+	s.Run("container_restart(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_restart", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_restart(name=nonexistent) returns not found error", func() {
+		s.WithError("POST", "/libpod/containers/{id}/restart", "/containers/{id}/restart",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_restart", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotEmpty(text, "error message should not be empty")
+	})
+
+	s.Run("container_restart(name=test-container) restarts container", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/test-container",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "running", Running: true},
+		})
+		s.WithContainerRestart()
+
+		toolResult, err := s.CallTool("container_restart", map[string]interface{}{
+			"name": "test-container",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns success response with container name", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.NotEmpty(text, "should return the container name or ID")
+		})
+
+		s.Run("mock server received restart request", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/{id}/restart"))
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerLogs() {
+	s.Run("container_logs(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_logs", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_logs(name=nonexistent) returns not found error", func() {
+		s.WithError("GET", "/libpod/containers/{id}/json", "/containers/{id}/json",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_logs", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotEmpty(text, "error message should not be empty")
+	})
+
+	s.Run("container_logs(name=test-container) returns logs", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:        "abc123def456",
+			Name:      "/test-container",
+			Image:     "sha256:abc123",
+			ImageName: "docker.io/library/nginx:latest",
+			Created:   "2024-01-01T00:00:00Z",
+			State: &test.ContainerState{
+				Status:    "running",
+				Running:   true,
+				StartedAt: "2024-01-01T00:00:00Z",
+			},
+		})
+		expectedLogs := "2024-01-01T00:00:00Z Starting nginx...\n2024-01-01T00:00:01Z nginx started successfully\n"
+		s.WithContainerLogs(expectedLogs)
+
+		toolResult, err := s.CallTool("container_logs", map[string]interface{}{
+			"name": "test-container",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns log content with expected format", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "Starting nginx", "should contain first log line")
+			s.Contains(text, "nginx started successfully", "should contain second log line")
+			s.Less(
+				strings.Index(text, "Starting nginx"),
+				strings.Index(text, "nginx started successfully"),
+				"log lines should appear in chronological order",
+			)
+		})
+
+		s.Run("mock server received logs request", func() {
+			s.True(s.MockServer.HasRequest("GET", "/libpod/containers/{id}/logs"))
+		})
+	})
+
+	s.Run("container_logs(stdout=false, stderr=false) returns error", func() {
+		toolResult, err := s.CallTool("container_logs", map[string]interface{}{
+			"name":   "test-container",
+			"stdout": false,
+			"stderr": false,
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "stdout or stderr")
+	})
+
+	s.Run("container_logs(follow=true, followPattern=invalid) returns error", func() {
+		toolResult, err := s.CallTool("container_logs", map[string]interface{}{
+			"name":          "test-container",
+			"follow":        true,
+			"followPattern": "[",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "followPattern")
+	})
+
+	s.Run("container_logs(follow=true, followPattern=...) stops when a line matches", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:        "abc123def456",
+			Name:      "/test-container",
+			Image:     "sha256:abc123",
+			ImageName: "docker.io/library/nginx:latest",
+			Created:   "2024-01-01T00:00:00Z",
+			State: &test.ContainerState{
+				Status:    "running",
+				Running:   true,
+				StartedAt: "2024-01-01T00:00:00Z",
+			},
+		})
+		s.WithContainerLogsFollow("Starting nginx...", "nginx listening on port 80", "Handling request")
+
+		toolResult, err := s.CallToolWithProgress("container_logs", map[string]interface{}{
+			"name":          "test-container",
+			"follow":        true,
+			"followPattern": "listening on port \\d+",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the lines collected until the match", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Starting nginx...\nnginx listening on port 80\n", text)
+		})
+
+		s.Run("sends the lines as progress notifications", func() {
+			s.Eventually(func() bool {
+				return len(s.ProgressMessages()) == 2
+			}, time.Second, 10*time.Millisecond)
+			s.Equal([]string{"Starting nginx...", "nginx listening on port 80"}, s.ProgressMessages())
+		})
+
+		s.Run("logs request follows the logs", func() {
+			req := s.PopLastCapturedRequest("GET", "/libpod/containers/{id}/logs")
+			s.Require().NotNil(req, "logs request should be captured")
+			s.Contains(req.Query, "follow=true")
+		})
+	})
+
+	s.Run("container_logs(follow=true, followDuration=1) stops after the duration", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:        "abc123def456",
+			Name:      "/test-container",
+			Image:     "sha256:abc123",
+			ImageName: "docker.io/library/nginx:latest",
+			Created:   "2024-01-01T00:00:00Z",
+			State: &test.ContainerState{
+				Status:    "running",
+				Running:   true,
+				StartedAt: "2024-01-01T00:00:00Z",
+			},
+		})
+		s.WithContainerLogsFollow("Starting nginx...", "nginx started successfully")
+
+		start := time.Now()
+		toolResult, err := s.CallTool("container_logs", map[string]interface{}{
+			"name":           "test-container",
+			"follow":         true,
+			"followDuration": 1,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the collected lines", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Starting nginx...\nnginx started successfully\n", text)
+		})
+
+		s.Run("returns once the duration elapses", func() {
+			s.GreaterOrEqual(time.Since(start), time.Second)
+			s.Less(time.Since(start), 5*time.Second)
+		})
+	})
+
+	s.Run("container_logs(tail=10, timestamps=true) sends log options", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:        "abc123def456",
+			Name:      "/test-container",
+			Image:     "sha256:abc123",
+			ImageName: "docker.io/library/nginx:latest",
+			Created:   "2024-01-01T00:00:00Z",
+			State: &test.ContainerState{
+				Status:    "running",
+				Running:   true,
+				StartedAt: "2024-01-01T00:00:00Z",
+			},
+		})
+		s.WithContainerLogs("2024-01-01T00:00:01Z nginx started successfully\n")
+
+		toolResult, err := s.CallTool("container_logs", map[string]interface{}{
+			"name":       "test-container",
+			"tail":       10,
+			"timestamps": true,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns log content", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "nginx started successfully")
+		})
+
+		s.Run("logs request includes options", func() {
+			req := s.PopLastCapturedRequest("GET", "/libpod/containers/{id}/logs")
+			s.Require().NotNil(req, "logs request should be captured")
+			s.Contains(req.Query, "tail=10")
+			s.Contains(req.Query, "timestamps=true")
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerPause() {
+	s.Run("container_pause(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_pause", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_pause(name=nonexistent) returns not found error", func() {
+		s.WithError("POST", "/libpod/containers/{id}/pause", "/containers/{id}/pause",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_pause", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotEmpty(text, "error message should not be empty")
+	})
+
+	s.Run("container_pause(name=test-container) pauses container", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/test-container",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "running", Running: true},
+		})
+		s.WithContainerPause()
+
+		toolResult, err := s.CallTool("container_pause", map[string]interface{}{
+			"name": "test-container",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns success response with container name", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.NotEmpty(text, "should return the container name or ID")
+		})
+
+		s.Run("mock server received pause request", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/{id}/pause"))
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerPort() {
+	s.Run("container_port(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_port", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_port(name=web) returns port bindings", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/web",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "running", Running: true},
+			NetworkSettings: &test.NetworkSettings{
+				Ports: map[string][]test.PortBinding{
+					"443/tcp":  {{HostIP: "127.0.0.1", HostPort: "8443"}},
+					"80/tcp":   {{HostIP: "", HostPort: "8080"}},
+					"53/udp":   {{HostIP: "0.0.0.0", HostPort: "5353"}},
+					"9000/tcp": nil,
+				},
+			},
+		})
+
+		toolResult, err := s.CallTool("container_port", map[string]interface{}{
+			"name": "web",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns bindings sorted by container port", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			lines := strings.Split(text, "\n")
+			s.Require().Len(lines, 4)
+			s.Regexp(`^HOST IP\s+HOST PORT\s+CONTAINER PORT\s+PROTOCOL$`, lines[0])
+			s.Regexp(`^0\.0\.0\.0\s+5353\s+53\s+udp$`, lines[1])
+			s.Regexp(`^0\.0\.0\.0\s+8080\s+80\s+tcp$`, lines[2])
+			s.Regexp(`^127\.0\.0\.1\s+8443\s+443\s+tcp$`, lines[3])
+		})
+	})
+
+	s.Run("container_port(name=nonexistent) returns not found error", func() {
+		s.WithError("GET", "/libpod/containers/{id}/json", "/containers/{id}/json",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_port", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
+func (s *ContainerSuite) TestContainerPrune() {
+	s.WithContainerList([]test.ContainerListResponse{
+		{
+			ID:      "abc123def456789",
+			Names:   []string{"old-job"},
+			Image:   "docker.io/library/alpine:latest",
+			Created: "2024-01-01T00:00:00Z",
+			State:   "exited",
+			Labels:  map[string]string{"env": "test"},
+			Size:    &test.ContainerSize{RwSize: 1500000},
+		},
+		{
+			ID:      "def456abc123789",
+			Names:   []string{"never-started"},
+			Image:   "docker.io/library/nginx:latest",
+			Created: "2024-01-01T00:00:00Z",
+			State:   "created",
+			Labels:  map[string]string{"env": "test"},
+			Size:    &test.ContainerSize{RwSize: 500000},
+		},
+	})
+	s.WithContainerPrune([]test.PruneReport{
+		{ID: "abc123def456789", Size: 1500000},
+		{ID: "def456abc123789", Size: 500000},
+	})
+
+	// listFilters returns the filters of the last container list request
+	listFilters := func() map[string][]string {
+		req := s.MockServer.GetRequest("GET", "/libpod/containers/json")
+		s.Require().NotNil(req, "container list request should be made")
+		query, err := url.ParseQuery(req.Query)
+		s.Require().NoError(err)
+		var filters map[string][]string
+		s.Require().NoError(json.Unmarshal([]byte(query.Get("filters")), &filters))
+		return filters
+	}
+
+	s.Run("container_prune() lists the containers that would be removed", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("container_prune", map[string]interface{}{})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the containers and reclaimable space", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "The following containers would be removed:")
+			s.Regexp(`(?m)^CONTAINER ID\s+NAME\s+SIZE$`, text)
+			s.Regexp(`(?m)^abc123def456\s+old-job\s+1\.5 MB$`, text)
+			s.Regexp(`(?m)^def456abc123\s+never-started\s+500\.0 KB$`, text)
+			s.Contains(text, "Total reclaimable space: 2.0 MB")
+		})
+
+		s.Run("lists the stopped containers", func() {
+			s.Equal(map[string][]string{"status": {"created", "exited"}}, listFilters())
+		})
+
+		s.Run("doesn't remove the containers", func() {
+			s.False(s.MockServer.HasRequest("POST", "/libpod/containers/prune"))
+		})
+	})
+
+	s.Run("container_prune(label, until) lists the stopped containers matching the filters", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("container_prune", map[string]interface{}{
+			"label": []interface{}{"env=test"},
+			"until": "24h",
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		s.Equal(map[string][]string{
+			"status": {"created", "exited"},
+			"label":  {"env=test"},
+			"until":  {"24h"},
+		}, listFilters())
+	})
+
+	s.Run("container_prune(label=key!=value) excludes the containers with the label", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("container_prune", map[string]interface{}{
+			"label": []interface{}{"env!=test"},
+		})
+
+		s.Run("lists the stopped containers without the label", func() {
+			s.Equal(map[string][]string{
+				"status": {"created", "exited"},
+				"label!": {"env=test"},
+			}, listFilters())
+		})
+
+		s.Run("returns no containers", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+			s.Equal("No containers would be removed", toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+
+	s.Run("container_prune(label=key!=value, confirm=true) sends the label! filter", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("container_prune", map[string]interface{}{
+			"label":   []interface{}{"env=test", "keep!=true"},
+			"confirm": true,
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		req := s.MockServer.GetRequest("POST", "/libpod/containers/prune")
+		s.Require().NotNil(req, "container prune request should be made")
+		query, err := url.ParseQuery(req.Query)
+		s.Require().NoError(err)
+		var filters map[string][]string
+		s.Require().NoError(json.Unmarshal([]byte(query.Get("filters")), &filters))
+		s.Equal(map[string][]string{"label": {"env=test"}, "label!": {"keep=true"}}, filters)
+	})
+
+	s.Run("container_prune(confirm=true) removes the containers", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("container_prune", map[string]interface{}{
+			"label":   []interface{}{"env=test"},
+			"confirm": true,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the removed containers and reclaimed space", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "Removed containers:")
+			s.Regexp(`(?m)^abc123def456\s+old-job\s+1\.5 MB$`, text)
+			s.Regexp(`(?m)^def456abc123\s+never-started\s+500\.0 KB$`, text)
+			s.Contains(text, "Total reclaimed space: 2.0 MB")
+		})
+
+		s.Run("sends the prune filters", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/containers/prune")
+			s.Require().NotNil(req, "container prune request should be made")
+			query, err := url.ParseQuery(req.Query)
+			s.Require().NoError(err)
+			var filters map[string][]string
+			s.Require().NoError(json.Unmarshal([]byte(query.Get("filters")), &filters))
+			s.Equal(map[string][]string{"label": {"env=test"}}, filters)
+		})
+	})
+
+	s.Run("container_prune() with no stopped containers", func() {
+		s.WithContainerList([]test.ContainerListResponse{})
+		toolResult, err := s.CallTool("container_prune", map[string]interface{}{})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		s.Equal("No containers would be removed", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *ContainerSuite) TestContainerUnpause() {
+	s.Run("container_unpause(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_unpause", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_unpause(name=nonexistent) returns not found error", func() {
+		s.WithError("POST", "/libpod/containers/{id}/unpause", "/containers/{id}/unpause",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_unpause", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotEmpty(text, "error message should not be empty")
+	})
+
+	s.Run("container_unpause(name=test-container) unpauses container", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/test-container",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "paused", Running: true, Paused: true},
+		})
+		s.WithContainerUnpause()
+
+		toolResult, err := s.CallTool("container_unpause", map[string]interface{}{
+			"name": "test-container",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns success response with container name", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.NotEmpty(text, "should return the container name or ID")
+		})
+
+		s.Run("mock server received unpause request", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/{id}/unpause"))
+		})
+	})
+}
+
+func (s *ContainerSuite) TestContainerUpdate() {
+	s.Run("container_update(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_update", map[string]interface{}{
+			"memory": "1g",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_update(name=test-container) without changes returns error", func() {
+		toolResult, err := s.CallTool("container_update", map[string]interface{}{
+			"name": "test-container",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "at least one resource limit or the restart policy must be specified")
+	})
+
+	s.Run("container_update(name=test-container, limits) updates container", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/test-container",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "running", Running: true},
+		})
+		s.WithContainerUpdate()
+
+		toolResult, err := s.CallTool("container_update", map[string]interface{}{
+			"name":          "test-container",
+			"memory":        "1g",
+			"memorySwap":    "2g",
+			"cpuQuota":      150000,
+			"cpuPeriod":     100000,
+			"cpuShares":     512,
+			"cpusetCpus":    "0-1",
+			"pidsLimit":     200,
+			"restartPolicy": "on-failure:3",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns container name", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "test-container")
+		})
+
+		req := s.MockServer.GetRequest("POST", "/libpod/containers/{id}/update")
+		s.Require().NotNil(req, "update request should be made")
+
+		s.Run("sends memory limits", func() {
+			s.Contains(req.Body, `"limit":1073741824`)
+			s.Contains(req.Body, `"swap":2147483648`)
+		})
+
+		s.Run("sends CPU limits", func() {
+			s.Contains(req.Body, `"quota":150000`)
+			s.Contains(req.Body, `"period":100000`)
+			s.Contains(req.Body, `"shares":512`)
+			s.Contains(req.Body, `"cpus":"0-1"`)
+		})
+
+		s.Run("sends pids limit", func() {
+			s.Contains(req.Body, `"pids":{"limit":200}`)
+		})
+
+		s.Run("sends restart policy", func() {
+			s.Contains(req.Query, "restartPolicy=on-failure")
+			s.Contains(req.Query, "restartRetries=3")
+		})
+	})
+
+	s.Run("container_update(memory=invalid) returns error", func() {
+		s.WithContainerUpdate()
+
+		toolResult, err := s.CallTool("container_update", map[string]interface{}{
+			"name":   "test-container",
+			"memory": "lots",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
+func (s *ContainerSuite) TestContainerWait() {
+	s.Run("container_wait(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_wait", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_wait(name=batch-job) returns exit code and final state", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/batch-job",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "exited", ExitCode: 3},
+		})
+		s.WithContainerWait(3)
+
+		toolResult, err := s.CallTool("container_wait", map[string]interface{}{
+			"name": "batch-job",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns final state and exit code", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Container batch-job met the wait condition\nState: exited\nExit code: 3", text)
+		})
+
+		s.Run("waits for the container", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/batch-job/wait"))
+		})
+	})
+
+	s.Run("container_wait(name=web, conditions=[healthy]) waits for the conditions", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/web",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "running", Running: true},
+		})
+		s.WithContainerWait(-1)
+
+		toolResult, err := s.CallTool("container_wait", map[string]interface{}{
+			"name":       "web",
+			"conditions": []interface{}{"healthy", "exited"},
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns final state without exit code", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "State: running")
+			s.NotContains(text, "Exit code")
+		})
+
+		s.Run("sends conditions", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/containers/web/wait")
+			s.Require().NotNil(req, "wait request should be made")
+			s.Contains(req.Query, "condition=healthy")
+			s.Contains(req.Query, "condition=exited")
+		})
+	})
+
+	s.Run("container_wait(name=web, timeout=1) returns error when the timeout expires", func() {
+		s.WithContainerWaitBlocked()
+
+		toolResult, err := s.CallTool("container_wait", map[string]interface{}{
+			"name":    "web",
+			"timeout": 1,
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "timed out after 1s waiting for container web")
+	})
+
+	s.Run("container_wait(name=nonexistent) returns not found error", func() {
+		s.WithError("POST", "/libpod/containers/{id}/wait", "/containers/{id}/wait",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_wait", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
+func (s *ContainerSuite) TestContainerKill() {
+	s.Run("container_kill(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_kill", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_kill(name=nonexistent) returns not found error", func() {
+		s.WithError("POST", "/libpod/containers/{id}/kill", "/containers/{id}/kill",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_kill", map[string]interface{}{
+			"name": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotEmpty(text, "error message should not be empty")
+	})
+
+	s.Run("container_kill(name=test-container) kills container", func() {
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/test-container",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "running", Running: true},
+		})
+		s.WithContainerKill()
+
+		toolResult, err := s.CallTool("container_kill", map[string]interface{}{
+			"name":   "test-container",
+			"signal": "SIGTERM",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns success response with container name", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.NotEmpty(text, "should return the container name or ID")
+		})
+
+		s.Run("mock server received kill request", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/{id}/kill"))
+		})
+
+		s.Run("kill request includes signal", func() {
+			req := s.PopLastCapturedRequest("POST", "/libpod/containers/{id}/kill")
+			s.Require().NotNil(req, "kill request should be captured")
+			s.Contains(req.Query, "signal=SIGTERM", "should contain the requested signal")
 		})
-		s.NoError(err)
-		s.True(toolResult.IsError, "tool result should indicate an error")
-		text := toolResult.Content[0].(*mcp.TextContent).Text
-		s.Contains(text, "name", "error should mention the parameter")
-		s.Contains(text, "must be a string", "error should indicate parameter must be a string")
 	})
+}
 
-	s.Run("container_inspect(name=nonexistent) returns not found error", func() {
-		s.WithError("GET", "/libpod/containers/{id}/json", "/containers/{id}/json",
-			404, "no such container: nonexistent")
+func (s *ContainerSuite) TestContainerStats() {
+	s.Run("container_stats(names=[web]) returns a snapshot", func() {
+		s.WithContainerStats([]test.ContainerStatsResponse{
+			{
+				ContainerID: "abc123def456789",
+				Name:        "web",
+				CPU:         25,
+				MemUsage:    100_000_000,
+				MemLimit:    1_000_000_000,
+				MemPerc:     10,
+				Network:     map[string]test.ContainerNetworkStats{"eth0": {RxBytes: 2_000, TxBytes: 1_000}},
+				PIDs:        3,
+			},
+		})
 
-		toolResult, err := s.CallTool("container_inspect", map[string]interface{}{
-			"name": "nonexistent",
+		toolResult, err := s.CallTool("container_stats", map[string]interface{}{
+			"names": []interface{}{"web"},
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the container statistics", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "abc123def456")
+			s.Contains(text, "web")
+			s.Contains(text, "25.00%")
+			s.Contains(text, "100MB / 1GB")
+			s.Contains(text, "2kB / 1kB")
+		})
+
+		s.Run("stats request is not streamed", func() {
+			req := s.PopLastCapturedRequest("GET", "/libpod/containers/stats")
+			s.Require().NotNil(req, "stats request should be captured")
+			s.Contains(req.Query, "containers=web")
+			s.Contains(req.Query, "stream=false")
 		})
-		s.NoError(err)
-		s.True(toolResult.IsError, "tool result should indicate an error")
-		text := toolResult.Content[0].(*mcp.TextContent).Text
-		s.NotEmpty(text, "error message should not be empty")
 	})
 
-	s.Run("container_inspect(name=test-container) returns container details", func() {
-		s.WithContainerInspect(test.ContainerInspectResponse{
-			ID:        "abc123def456",
-			Name:      "/test-container",
-			Image:     "sha256:abc123",
-			ImageName: "docker.io/library/nginx:latest",
-			Created:   "2024-01-01T00:00:00Z",
-			State: &test.ContainerState{
-				Status:    "running",
-				Running:   true,
-				StartedAt: "2024-01-01T00:00:00Z",
+	s.Run("container_stats(window=1) returns min/avg/max", func() {
+		s.WithContainerStats(
+			[]test.ContainerStatsResponse{
+				{ContainerID: "abc123def456789", Name: "web", CPU: 80, CPUNano: 1_000_000_000, SystemNano: 10_000_000_000, MemUsage: 100_000_000, PIDs: 3},
 			},
-			Config: &test.ContainerConfig{
-				Image:    "docker.io/library/nginx:latest",
-				Hostname: "abc123def456",
-				Env:      []string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"},
+			[]test.ContainerStatsResponse{
+				{ContainerID: "abc123def456789", Name: "web", CPU: 80, CPUNano: 1_500_000_000, SystemNano: 11_000_000_000, MemUsage: 200_000_000, PIDs: 5},
 			},
-		})
+		)
 
-		toolResult, err := s.CallTool("container_inspect", map[string]interface{}{
-			"name": "test-container",
+		toolResult, err := s.CallTool("container_stats", map[string]interface{}{
+			"window": 1,
 		})
 
 		s.Run("returns OK", func() {
@@ -268,22 +2124,53 @@ func (s *ContainerSuite) TestContainerInspect() {
 			s.False(toolResult.IsError)
 		})
 
-		s.Run("returns container details with expected format", func() {
+		s.Run("returns the aggregated statistics", func() {
 			text := toolResult.Content[0].(*mcp.TextContent).Text
-			s.Contains(text, "abc123def456", "should contain container ID")
-			s.Contains(text, "nginx", "should contain image name")
-			s.Contains(text, "running", "should contain container state")
+			s.Regexp(`(?m)^abc123def456\s+web\s+2\s+CPU %\s+50.00%\s+50.00%\s+50.00%$`, text,
+				"CPU should be computed from the CPU time consumed between samples")
+			s.Regexp(`(?m)^abc123def456\s+web\s+2\s+MEM USAGE\s+100MB\s+150MB\s+200MB$`, text)
+			s.Regexp(`(?m)^abc123def456\s+web\s+2\s+PIDS\s+3\s+4\s+5$`, text)
 		})
+	})
 
-		s.Run("mock server received inspect request", func() {
-			s.True(s.MockServer.HasRequest("GET", "/libpod/containers/{id}/json"))
+	s.Run("container_stats(window=60) stops sampling when the call is cancelled", func() {
+		s.WithContainerStats([]test.ContainerStatsResponse{
+			{ContainerID: "abc123def456789", Name: "web", CPU: 80, MemUsage: 100_000_000, PIDs: 3},
+		})
+		s.MockServer.ClearRequests()
+		// statsRequests returns the number of stats samples taken
+		statsRequests := func() int {
+			count := 0
+			for _, req := range s.MockServer.Requests() {
+				if strings.HasSuffix(req.Path, "/containers/stats") {
+					count++
+				}
+			}
+			return count
+		}
+		ctx, cancel := context.WithTimeout(s.T().Context(), 1500*time.Millisecond)
+		defer cancel()
+
+		_, err := s.CallToolWithContext(ctx, "container_stats", map[string]interface{}{
+			"window":   60,
+			"interval": 1,
+		})
+
+		s.Run("returns error", func() {
+			s.Error(err)
+		})
+
+		s.Run("does not take more samples", func() {
+			sampled := statsRequests()
+			time.Sleep(1500 * time.Millisecond)
+			s.Equal(sampled, statsRequests(), "sampling should stop once the call is cancelled")
 		})
 	})
 }
 
-func (s *ContainerSuite) TestContainerStop() {
-	s.Run("container_stop(name=nil) returns error", func() {
-		toolResult, err := s.CallTool("container_stop", map[string]interface{}{})
+func (s *ContainerSuite) TestContainerTop() {
+	s.Run("container_top(name=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_top", map[string]interface{}{})
 		s.NoError(err)
 		s.True(toolResult.IsError, "tool result should indicate an error")
 		text := toolResult.Content[0].(*mcp.TextContent).Text
@@ -291,42 +2178,18 @@ func (s *ContainerSuite) TestContainerStop() {
 		s.Contains(text, "required", "error should indicate parameter is required")
 	})
 
-	s.Run("container_stop(name=nonexistent) returns not found error", func() {
-		s.WithError("GET", "/libpod/containers/{id}/json", "/containers/{id}/json",
-			404, "no such container: nonexistent")
-
-		toolResult, err := s.CallTool("container_stop", map[string]interface{}{
-			"name": "nonexistent",
-		})
-		s.NoError(err)
-		s.True(toolResult.IsError, "tool result should indicate an error")
-		text := toolResult.Content[0].(*mcp.TextContent).Text
-		s.NotEmpty(text, "error message should not be empty")
-	})
-
-	s.Run("container_stop(name=test-container) stops container", func() {
-		// Podman first looks up container by name, then inspects, then stops
-		s.WithContainerList([]test.ContainerListResponse{
-			{
-				ID:        "abc123def456",
-				Names:     []string{"test-container"},
-				Image:     "docker.io/library/nginx:latest",
-				State:     "running",
-				Created:   "2024-01-01T00:00:00Z",
-				Command:   []string{"/bin/sh"},
-				StartedAt: 1704067200,
+	s.Run("container_top(name=web, psArgs=[pid, user, args]) returns processes", func() {
+		s.WithContainerTop(test.ContainerTopResponse{
+			Titles: []string{"PID", "USER", "COMMAND"},
+			Processes: [][]string{
+				{"1", "root", "nginx: master process nginx -g daemon off;"},
+				{"29", "nginx", "nginx: worker process"},
 			},
 		})
-		s.WithContainerInspect(test.ContainerInspectResponse{
-			ID:      "abc123def456",
-			Name:    "/test-container",
-			Created: "2024-01-01T00:00:00Z",
-			State:   &test.ContainerState{Status: "running", Running: true},
-		})
-		s.WithContainerStop()
 
-		toolResult, err := s.CallTool("container_stop", map[string]interface{}{
-			"name": "test-container",
+		toolResult, err := s.CallTool("container_top", map[string]interface{}{
+			"name":   "web",
+			"psArgs": []interface{}{"pid", "user", "args"},
 		})
 
 		s.Run("returns OK", func() {
@@ -334,13 +2197,18 @@ func (s *ContainerSuite) TestContainerStop() {
 			s.False(toolResult.IsError)
 		})
 
-		s.Run("returns success response with container name", func() {
+		s.Run("returns processes table", func() {
 			text := toolResult.Content[0].(*mcp.TextContent).Text
-			s.Contains(text, "test-container", "should contain the stopped container name")
+			s.Regexp(`(?m)^PID\s+USER\s+COMMAND$`, text)
+			s.Regexp(`(?m)^1\s+root\s+nginx: master process nginx -g daemon off;$`, text)
+			s.Regexp(`(?m)^29\s+nginx\s+nginx: worker process$`, text)
 		})
 
-		s.Run("mock server received stop request", func() {
-			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/{id}/stop"))
+		s.Run("top request includes ps args", func() {
+			req := s.PopLastCapturedRequest("GET", "/libpod/containers/{id}/top")
+			s.Require().NotNil(req, "top request should be captured")
+			s.Contains(req.Query, "ps_args=pid")
+			s.Contains(req.Query, "ps_args=args")
 		})
 	})
 }
@@ -409,6 +2277,63 @@ func (s *ContainerSuite) TestContainerRemove() {
 	})
 }
 
+func (s *ContainerSuite) TestContainerRename() {
+	s.Run("container_rename(newName=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_rename", map[string]interface{}{
+			"name": "web",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "newName", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("container_rename(name=web, newName=frontend) renames the container", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/web",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "running", Running: true},
+		})
+		s.WithContainerRename()
+
+		toolResult, err := s.CallTool("container_rename", map[string]interface{}{
+			"name":    "web",
+			"newName": "frontend",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns new name", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("frontend", text)
+		})
+
+		s.Run("sends new name", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/containers/web/rename")
+			s.Require().NotNil(req, "rename request should be made")
+			s.Contains(req.Query, "name=frontend")
+		})
+	})
+
+	s.Run("container_rename(name=nonexistent) returns not found error", func() {
+		s.WithError("POST", "/libpod/containers/{id}/rename", "/containers/{id}/rename",
+			404, "no such container: nonexistent")
+
+		toolResult, err := s.CallTool("container_rename", map[string]interface{}{
+			"name":    "nonexistent",
+			"newName": "frontend",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
 func (s *ContainerSuite) TestContainerRun() {
 	s.Run("container_run(imageName=nil) returns error", func() {
 		toolResult, err := s.CallTool("container_run", map[string]interface{}{})
@@ -582,6 +2507,84 @@ func (s *ContainerSuite) TestContainerRun() {
 		})
 	})
 
+	s.Run("container_run(name, volumes, network, ...) includes run options", func() {
+		s.WithContainerRun("container-with-options")
+
+		toolResult, err := s.CallTool("container_run", map[string]interface{}{
+			"imageName":     "example.com/org/image:tag",
+			"name":          "my-container",
+			"volumes":       []interface{}{"data:/data:ro"},
+			"network":       "my-network",
+			"command":       []interface{}{"npm", "start"},
+			"entrypoint":    []interface{}{"/bin/sh", "-c"},
+			"workdir":       "/app",
+			"user":          "1000:1000",
+			"labels":        []interface{}{"app=web"},
+			"memory":        "512m",
+			"cpus":          1.5,
+			"restartPolicy": "on-failure:3",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		req := s.PopLastCapturedRequest("POST", "/libpod/containers/create")
+		s.Require().NotNil(req, "create request should be captured")
+		s.Run("create request includes name", func() {
+			s.Contains(req.Body, `"name":"my-container"`)
+		})
+		s.Run("create request includes volume mount", func() {
+			s.Contains(req.Body, `"Name":"data"`)
+			s.Contains(req.Body, `"Dest":"/data"`)
+		})
+		s.Run("create request includes network", func() {
+			s.Contains(req.Body, `"my-network"`)
+		})
+		s.Run("create request includes command and entrypoint", func() {
+			s.Contains(req.Body, `"command":["npm","start"]`)
+			s.Contains(req.Body, `"entrypoint":["/bin/sh","-c"]`)
+		})
+		s.Run("create request includes workdir and user", func() {
+			s.Contains(req.Body, `"work_dir":"/app"`)
+			s.Contains(req.Body, `"user":"1000:1000"`)
+		})
+		s.Run("create request includes labels", func() {
+			s.Contains(req.Body, `"app":"web"`)
+		})
+		s.Run("create request includes resource limits", func() {
+			s.Contains(req.Body, `"limit":536870912`)
+			s.Contains(req.Body, `"quota":150000`)
+		})
+		s.Run("create request includes restart policy", func() {
+			s.Contains(req.Body, `"restart_policy":"on-failure"`)
+			s.Contains(req.Body, `"restart_tries":3`)
+		})
+		s.Run("create request does not auto-remove the container", func() {
+			s.NotContains(req.Body, `"remove":true`)
+		})
+	})
+
+	s.Run("container_run removes the container on exit by default", func() {
+		s.WithContainerRun("container-auto-remove")
+
+		toolResult, err := s.CallTool("container_run", map[string]interface{}{
+			"imageName": "example.com/org/image:tag",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("create request auto-removes the container", func() {
+			req := s.PopLastCapturedRequest("POST", "/libpod/containers/create")
+			s.Require().NotNil(req, "create request should be captured")
+			s.Contains(req.Body, `"remove":true`)
+		})
+	})
+
 	s.Run("container_run returns error when start fails", func() {
 		// Pull succeeds
 		pullHandler := func(w http.ResponseWriter, _ *http.Request) {
@@ -686,60 +2689,36 @@ func (s *ContainerSuite) TestContainerRun() {
 	})
 }
 
-// ContainerLogsSuite tests container logs using CLI implementation only.
-// The API binding's streaming Logs() function requires the server to close the
-// connection to signal EOF, which the mock HTTP server cannot do properly.
-type ContainerLogsSuite struct {
-	test.McpSuite
-}
-
-func TestContainerLogsSuite(t *testing.T) {
-	suite.Run(t, &ContainerLogsSuite{
-		McpSuite: test.McpSuite{Config: config.Config{PodmanImpl: "cli"}},
-	})
-}
-
-func (s *ContainerLogsSuite) TestContainerLogs() {
-	s.Run("container_logs(name=nil) returns error", func() {
-		toolResult, err := s.CallTool("container_logs", map[string]interface{}{})
-		s.NoError(err)
-		s.True(toolResult.IsError, "tool result should indicate an error")
-		text := toolResult.Content[0].(*mcp.TextContent).Text
-		s.Contains(text, "name", "error should mention the missing parameter")
-		s.Contains(text, "required", "error should indicate parameter is required")
-	})
-
-	s.Run("container_logs(name=nonexistent) returns not found error", func() {
-		s.WithError("GET", "/libpod/containers/{id}/json", "/containers/{id}/json",
-			404, "no such container: nonexistent")
-
-		toolResult, err := s.CallTool("container_logs", map[string]interface{}{
-			"name": "nonexistent",
+func (s *ContainerSuite) TestContainerRunCommand() {
+	s.Run("container_run_command(command=nil) returns error", func() {
+		toolResult, err := s.CallTool("container_run_command", map[string]interface{}{
+			"imageName": "golang:1.25",
 		})
 		s.NoError(err)
 		s.True(toolResult.IsError, "tool result should indicate an error")
 		text := toolResult.Content[0].(*mcp.TextContent).Text
-		s.NotEmpty(text, "error message should not be empty")
+		s.Contains(text, "command", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
 	})
 
-	s.Run("container_logs(name=test-container) returns logs", func() {
+	s.Run("container_run_command(imageName=golang:1.25, command=[go, test]) returns the command output", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerRun("abc123def456")
+		s.WithContainerWait(1)
 		s.WithContainerInspect(test.ContainerInspectResponse{
-			ID:        "abc123def456",
-			Name:      "/test-container",
-			Image:     "sha256:abc123",
-			ImageName: "docker.io/library/nginx:latest",
-			Created:   "2024-01-01T00:00:00Z",
-			State: &test.ContainerState{
-				Status:    "running",
-				Running:   true,
-				StartedAt: "2024-01-01T00:00:00Z",
-			},
+			ID:      "abc123def456",
+			Name:    "/one-shot",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "exited", ExitCode: 1},
 		})
-		expectedLogs := "2024-01-01T00:00:00Z Starting nginx...\n2024-01-01T00:00:01Z nginx started successfully\n"
-		s.WithContainerLogs(expectedLogs)
+		s.WithContainerOutput("--- FAIL: TestAdd\n", "exit status 1\n")
+		s.WithContainerRemove()
 
-		toolResult, err := s.CallTool("container_logs", map[string]interface{}{
-			"name": "test-container",
+		toolResult, err := s.CallTool("container_run_command", map[string]interface{}{
+			"imageName": "golang:1.25",
+			"command":   []interface{}{"go", "test", "./..."},
+			"volumes":   []interface{}{"go-build-cache:/root/.cache/go-build"},
+			"workdir":   "/src",
 		})
 
 		s.Run("returns OK", func() {
@@ -747,19 +2726,63 @@ func (s *ContainerLogsSuite) TestContainerLogs() {
 			s.False(toolResult.IsError)
 		})
 
-		s.Run("returns log content with expected format", func() {
+		s.Run("returns exit code, stdout and stderr", func() {
 			text := toolResult.Content[0].(*mcp.TextContent).Text
-			s.Contains(text, "Starting nginx", "should contain first log line")
-			s.Contains(text, "nginx started successfully", "should contain second log line")
-			s.Less(
-				strings.Index(text, "Starting nginx"),
-				strings.Index(text, "nginx started successfully"),
-				"log lines should appear in chronological order",
-			)
+			s.Equal("Exit code: 1\nStdout:\n--- FAIL: TestAdd\nStderr:\nexit status 1", text)
 		})
 
-		s.Run("mock server received logs request", func() {
-			s.True(s.MockServer.HasRequest("GET", "/libpod/containers/{id}/logs"))
+		s.Run("creates the container with the command", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/containers/create")
+			s.Require().NotNil(req, "create request should be made")
+			s.Contains(req.Body, `"command":["go","test","./..."]`)
+			s.Contains(req.Body, `"work_dir":"/src"`)
+			s.NotContains(req.Body, `"publish_image_ports":true`)
+		})
+
+		s.Run("waits for the container", func() {
+			s.True(s.MockServer.HasRequest("POST", "/libpod/containers/abc123def456/wait"))
+		})
+
+		s.Run("removes the container", func() {
+			s.True(s.MockServer.HasRequest("DELETE", "/libpod/containers/abc123def456"))
+		})
+	})
+
+	s.Run("container_run_command(timeout=1) kills the container when the timeout expires", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerRun("abc123def456")
+		s.WithContainerWaitBlocked()
+		s.WithContainerRemove()
+
+		toolResult, err := s.CallTool("container_run_command", map[string]interface{}{
+			"imageName": "golang:1.25",
+			"command":   []interface{}{"sleep", "infinity"},
+			"timeout":   1,
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "timed out after 1s waiting for container abc123def456")
+		})
+
+		s.Run("removes the container", func() {
+			req := s.MockServer.GetRequest("DELETE", "/libpod/containers/abc123def456")
+			s.Require().NotNil(req, "remove request should be made")
+			s.Contains(req.Query, "force=true")
+		})
+	})
+
+	s.Run("container_run_command(imageName=nonexistent:latest) returns image not found error", func() {
+		s.WithError("POST", "/libpod/containers/create", "/containers/create",
+			404, "no such image: nonexistent:latest")
+
+		toolResult, err := s.CallTool("container_run_command", map[string]interface{}{
+			"imageName": "nonexistent:latest",
+			"command":   []interface{}{"true"},
 		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
 	})
 }
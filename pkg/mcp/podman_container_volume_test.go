@@ -0,0 +1,145 @@
+package mcp_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/manusa/podman-mcp-server/internal/test"
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// ContainerVolumeSuite tests the container bind mounts with an allowed host directory configured.
+type ContainerVolumeSuite struct {
+	test.McpSuite
+}
+
+func TestContainerVolumeSuiteWithAllImplementations(t *testing.T) {
+	for _, impl := range test.AvailableImplementations() {
+		t.Run(impl, func(t *testing.T) {
+			suite.Run(t, &ContainerVolumeSuite{
+				McpSuite: test.McpSuite{Config: config.Config{
+					PodmanImpl:      impl,
+					AllowedHostDirs: []string{t.TempDir()},
+				}},
+			})
+		})
+	}
+}
+
+// allowedDir returns the (symlink resolved) allowed host directory.
+func (s *ContainerVolumeSuite) allowedDir() string {
+	dir, err := filepath.EvalSymlinks(s.Config.AllowedHostDirs[0])
+	s.Require().NoError(err)
+	return dir
+}
+
+func (s *ContainerVolumeSuite) TestContainerRunVolumes() {
+	s.WithContainerRun("container-with-volumes")
+
+	s.Run("container_run(volumes=[allowed dir bind mount, named volume]) mounts the volumes", func() {
+		s.MockServer.ClearRequests()
+		project := filepath.Join(s.allowedDir(), "project")
+		s.Require().NoError(os.Mkdir(project, 0o755))
+
+		toolResult, err := s.CallTool("container_run", map[string]interface{}{
+			"imageName": "example.com/org/image:tag",
+			"volumes":   []interface{}{project + ":/src:ro", "data:/data"},
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		req := s.MockServer.GetRequest("POST", "/libpod/containers/create")
+		s.Require().NotNil(req, "create request should be made")
+		s.Run("create request includes the bind mount", func() {
+			s.Contains(req.Body, `"destination":"/src"`)
+			s.Contains(req.Body, `"source":"`+project+`"`)
+		})
+		s.Run("create request includes the named volume", func() {
+			s.Contains(req.Body, `"Name":"data"`)
+			s.Contains(req.Body, `"Dest":"/data"`)
+		})
+	})
+
+	for _, volume := range []string{"/:/host", "/etc:/etc:ro", "./data:/data", "/home/user/project:/src"} {
+		s.Run("container_run(volumes=["+volume+"]) returns error", func() {
+			s.MockServer.ClearRequests()
+
+			toolResult, err := s.CallTool("container_run", map[string]interface{}{
+				"imageName": "example.com/org/image:tag",
+				"volumes":   []interface{}{volume},
+			})
+
+			s.Run("returns error", func() {
+				s.NoError(err)
+				s.True(toolResult.IsError, "tool result should indicate an error")
+				s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid volume "+volume)
+			})
+
+			s.Run("does not create the container", func() {
+				s.False(s.MockServer.HasRequest("POST", "/libpod/containers/create"))
+			})
+		})
+	}
+}
+
+func (s *ContainerVolumeSuite) TestContainerRunCommandVolumes() {
+	s.Run("container_run_command(volumes=[bind mount outside allowed dirs]) returns error", func() {
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("container_run_command", map[string]interface{}{
+			"imageName": "golang:1.25",
+			"command":   []interface{}{"cat", "/host/etc/shadow"},
+			"volumes":   []interface{}{"/:/host"},
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "is not located in any of the allowed host directories")
+		})
+
+		s.Run("does not create the container", func() {
+			s.False(s.MockServer.HasRequest("POST", "/libpod/containers/create"))
+		})
+	})
+
+	s.Run("container_run_command(volumes=[allowed dir bind mount]) mounts the directory", func() {
+		s.MockServer.ClearRequests()
+		s.WithContainerRun("abc123def456")
+		s.WithContainerWait(0)
+		s.WithContainerInspect(test.ContainerInspectResponse{
+			ID:      "abc123def456",
+			Name:    "/one-shot",
+			Created: "2024-01-01T00:00:00Z",
+			State:   &test.ContainerState{Status: "exited"},
+		})
+		s.WithContainerOutput("ok\n", "")
+		s.WithContainerRemove()
+
+		toolResult, err := s.CallTool("container_run_command", map[string]interface{}{
+			"imageName": "golang:1.25",
+			"command":   []interface{}{"go", "test", "./..."},
+			"volumes":   []interface{}{s.allowedDir() + ":/src"},
+			"workdir":   "/src",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("create request includes the bind mount", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/containers/create")
+			s.Require().NotNil(req, "create request should be made")
+			s.Contains(req.Body, `"source":"`+s.allowedDir()+`"`)
+		})
+	})
+}
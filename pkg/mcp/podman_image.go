@@ -2,8 +2,13 @@ package mcp
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/manusa/podman-mcp-server/pkg/api"
+	"github.com/manusa/podman-mcp-server/pkg/podman"
 )
 
 func initImageTools() []api.ServerTool {
@@ -11,7 +16,7 @@ func initImageTools() []api.ServerTool {
 		{
 			Tool: api.Tool{
 				Name:        "image_build",
-				Description: "Build a Docker or Podman image from a Dockerfile, Podmanfile, or Containerfile",
+				Description: "Build a Docker or Podman image from a Dockerfile, Podmanfile, or Containerfile, optionally with build arguments, a target stage and labels. The Containerfile and build context can be read from the server filesystem, or provided inline (containerFileContent, contextFiles or contextArchive) when the client doesn't share the server filesystem. The build output lines are sent as progress notifications while the build runs (the progress counts the lines, the build steps are reported by the STEP n/m lines), the result includes its last lines and the image ID",
 				Annotations: api.ToolAnnotations{
 					Title:           "Image: Build",
 					ReadOnlyHint:    ptr(false),
@@ -24,18 +29,117 @@ func initImageTools() []api.ServerTool {
 					Properties: map[string]api.Property{
 						"containerFile": {
 							Type:        "string",
-							Description: "The absolute path to the Dockerfile, Podmanfile, or Containerfile to build the image from",
+							Description: "The absolute path to the Dockerfile, Podmanfile, or Containerfile to build the image from. If the server restricts the host access (--allowed-host-dir), it must be located in one of the allowed directories. If the build context is provided inline (contextFiles or contextArchive), the path relative to it (defaults to Containerfile). Required unless containerFileContent or an inline build context is provided",
+						},
+						"containerFileContent": {
+							Type:        "string",
+							Description: "The content of the Dockerfile, Podmanfile, or Containerfile to build the image from, instead of a containerFile path. (Optional)",
+						},
+						"contextDir": {
+							Type:        "string",
+							Description: "The absolute path to the build context directory, the files of this directory can be copied into the image (COPY and ADD instructions). If the server restricts the host access (--allowed-host-dir), it must be located in one of the allowed directories. (Optional, defaults to the directory of the containerFile)",
+						},
+						"contextFiles": {
+							Type:        "object",
+							Description: "The files of the build context provided inline, instead of a contextDir, mapping each path relative to the build context to the file content. Example: {\"index.html\": \"<h1>Hello</h1>\", \"conf/app.yaml\": \"port: 8080\"}. (Optional)",
+							AdditionalProperties: &api.Property{
+								Type: "string",
+							},
+						},
+						"contextArchive": {
+							Type:        "string",
+							Description: "The build context provided inline as a base64 encoded tar archive (optionally gzip compressed), instead of a contextDir. Useful for binary files. (Optional)",
 						},
 						"imageName": {
 							Type:        "string",
 							Description: "Specifies the name which is assigned to the resulting image if the build process completes successfully (--tag, -t)",
 						},
+						"tags": {
+							Type:        "array",
+							Description: "Additional names (and tags) assigned to the resulting image. Example: [\"registry.local/myapp:1.2.3\", \"registry.local/myapp:latest\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"buildArgs": {
+							Type:        "array",
+							Description: "Build-time variables for the ARG instructions in the format KEY=VALUE. Example: [\"VERSION=1.2.3\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"target": {
+							Type:        "string",
+							Description: "The stage of a multi-stage Containerfile to build. (Optional, defaults to the last stage)",
+						},
+						"labels": {
+							Type:        "array",
+							Description: "Metadata labels added to the image in the format key=value. Example: [\"org.opencontainers.image.version=1.2.3\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"noCache": {
+							Type:        "boolean",
+							Description: "Build all the layers without using the cached ones (--no-cache). (Optional, default false)",
+						},
+						"pull": {
+							Type:        "boolean",
+							Description: "Always pull the base images, even if they are present locally (--pull=always). (Optional, default false)",
+						},
 					},
-					Required: []string{"containerFile"},
 				},
 			},
 			Handler: imageBuild,
 		},
+		{
+			Tool: api.Tool{
+				Name:        "image_history",
+				Description: "Shows the layer history of a Docker or Podman image with the specified image ID or name, with the size and the Containerfile instruction that created each layer, from the most recent to the base layer",
+				Annotations: api.ToolAnnotations{
+					Title:           "Image: History",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"imageName": {
+							Type:        "string",
+							Description: "Docker or Podman container image ID or name to show the history of",
+						},
+					},
+					Required: []string{"imageName"},
+				},
+			},
+			Handler: imageHistory,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "image_inspect",
+				Description: "Displays the low-level information and configuration of a Docker or Podman image with the specified image ID or name, including its entrypoint, command, exposed ports, environment, labels and architecture",
+				Annotations: api.ToolAnnotations{
+					Title:           "Image: Inspect",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"imageName": {
+							Type:        "string",
+							Description: "Docker or Podman container image ID or name to display the information",
+						},
+					},
+					Required: []string{"imageName"},
+				},
+			},
+			Handler: imageInspect,
+		},
 		{
 			Tool: api.Tool{
 				Name:        "image_list",
@@ -53,6 +157,68 @@ func initImageTools() []api.ServerTool {
 			},
 			Handler: imageList,
 		},
+		{
+			Tool: api.Tool{
+				Name:        "image_load",
+				Description: "Loads the Docker or Podman images from a docker-archive or oci-archive tarball, or an oci-dir directory, into the local machine storage. Useful to move images between machines without a registry. The host path must be located in one of the directories the server allows host access to (--allowed-host-dir)",
+				Annotations: api.ToolAnnotations{
+					Title:           "Image: Load",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"path": {
+							Type:        "string",
+							Description: "Absolute host path of the image archive or directory to load the images from. Example: /home/user/images/myapp.tar",
+						},
+					},
+					Required: []string{"path"},
+				},
+			},
+			Handler: imageLoad,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "image_prune",
+				Description: "Removes the dangling (untagged) Docker or Podman images, or all the images not used by any container, optionally filtered by label or creation time. By default nothing is removed: the images that would be removed and the reclaimable space are listed for review. Call again with confirm set to true to actually remove them",
+				Annotations: api.ToolAnnotations{
+					Title:           "Image: Prune",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(true),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"all": {
+							Type:        "boolean",
+							Description: "Remove all the images not used by any container, not only the dangling ones. (Optional, default false)",
+						},
+						"label": {
+							Type:        "array",
+							Description: "Only remove the images with all the labels, specified as key, key=value, or key!=value to exclude them. Example: [\"stage=build\"]. (Optional)",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"until": {
+							Type:        "string",
+							Description: "Only remove the images created before the timestamp or relative duration. Example: 24h. (Optional)",
+						},
+						"confirm": {
+							Type:        "boolean",
+							Description: "Set to true to remove the images, otherwise they're only listed. (Optional, default false)",
+						},
+					},
+				},
+			},
+			Handler: imagePrune,
+		},
 		{
 			Tool: api.Tool{
 				Name:        "image_pull",
@@ -125,16 +291,163 @@ func initImageTools() []api.ServerTool {
 			},
 			Handler: imageRemove,
 		},
+		{
+			Tool: api.Tool{
+				Name:        "image_save",
+				Description: "Saves one or more Docker or Podman images from the local machine storage to a docker-archive or oci-archive tarball, or an oci-dir directory, that can be loaded with image_load. Useful to move images between machines without a registry. The host path must be located in one of the directories the server allows host access to (--allowed-host-dir)",
+				Annotations: api.ToolAnnotations{
+					Title:           "Image: Save",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(true),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"images": {
+							Type:        "array",
+							Description: "Docker or Podman container image IDs or names to save. Example: [\"myapp:1.2.3\", \"redis:7\"]",
+							Items: &api.Property{
+								Type: "string",
+							},
+						},
+						"path": {
+							Type:        "string",
+							Description: "Absolute host path of the archive file (or directory for the oci-dir format) to save the images to, an existing archive is overwritten. Example: /home/user/images/myapp.tar",
+						},
+						"format": {
+							Type:        "string",
+							Description: "Format of the saved images: docker-archive, oci-archive or oci-dir. Only docker-archive supports saving multiple images. (Optional, defaults to docker-archive)",
+						},
+					},
+					Required: []string{"images", "path"},
+				},
+			},
+			Handler: imageSave,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "image_tag",
+				Description: "Adds a new name (and tag) to a Docker or Podman image in the local machine storage, for example to name an image after the registry it's pushed to",
+				Annotations: api.ToolAnnotations{
+					Title:           "Image: Tag",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"imageName": {
+							Type:        "string",
+							Description: "Docker or Podman container image ID or name to tag. Example: myapp:dev",
+						},
+						"targetName": {
+							Type:        "string",
+							Description: "New name to add to the image, the tag defaults to latest if not specified. Example: registry.local/myapp:1.2.3",
+						},
+					},
+					Required: []string{"imageName", "targetName"},
+				},
+			},
+			Handler: imageTag,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "image_untag",
+				Description: "Removes a name (and tag) from a Docker or Podman image in the local machine storage, or all its names if no tag is specified. The image itself is kept",
+				Annotations: api.ToolAnnotations{
+					Title:           "Image: Untag",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(true),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"imageName": {
+							Type:        "string",
+							Description: "Docker or Podman container image ID or name to untag",
+						},
+						"tag": {
+							Type:        "string",
+							Description: "Name to remove from the image, the tag defaults to latest if not specified. Example: myapp:dev. (Optional, all the names are removed if not specified)",
+						},
+					},
+					Required: []string{"imageName"},
+				},
+			},
+			Handler: imageUntag,
+		},
 	}
 }
 
-func imageBuild(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	containerFile, err := params.RequiredString("containerFile")
+func imageBuild(ctx context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	opts := podman.ImageBuildOptions{
+		ContainerFile:        params.GetString("containerFile", ""),
+		ContainerFileContent: params.GetString("containerFileContent", ""),
+		ContextDir:           params.GetString("contextDir", ""),
+		ContextFiles:         params.GetStringMap("contextFiles"),
+		BuildArgs:            params.GetStringArray("buildArgs"),
+		Target:               params.GetString("target", ""),
+		Labels:               params.GetStringArray("labels"),
+		NoCache:              params.GetBool("noCache", false),
+		Pull:                 params.GetBool("pull", false),
+	}
+	if contextArchive := params.GetString("contextArchive", ""); contextArchive != "" {
+		archive, err := base64.StdEncoding.DecodeString(contextArchive)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("contextArchive is not valid base64: %w", err)), nil
+		}
+		opts.ContextArchive = archive
+	}
+	if opts.ContainerFile == "" && opts.ContainerFileContent == "" && len(opts.ContextFiles) == 0 && len(opts.ContextArchive) == 0 {
+		return api.NewToolCallResult("", errors.New("containerFile parameter required, or containerFileContent to build from an inline Containerfile")), nil
+	}
+	// The build can read the files of the host directories into the image,
+	// if the server restricts the host access, restrict them to the allowed host directories
+	if len(params.Config.AllowedHostDirs) > 0 {
+		var err error
+		if opts.ContextDir != "" {
+			if opts.ContextDir, err = params.Config.ResolveHostPath(opts.ContextDir); err != nil {
+				return api.NewToolCallResult("", err), nil
+			}
+		}
+		if opts.ContainerFile != "" && len(opts.ContextFiles) == 0 && len(opts.ContextArchive) == 0 {
+			if opts.ContainerFile, err = params.Config.ResolveHostPath(opts.ContainerFile); err != nil {
+				return api.NewToolCallResult("", err), nil
+			}
+		}
+	}
+	if imageName := params.GetString("imageName", ""); imageName != "" {
+		opts.Tags = append(opts.Tags, imageName)
+	}
+	opts.Tags = append(opts.Tags, params.GetStringArray("tags")...)
+	// The progress notifications count the output lines, the build steps are only reported by the STEP n/m lines
+	result, err := params.Podman.ImageBuild(ctx, opts, func(line string) {
+		params.Notify(ctx, line)
+	})
+	return api.NewToolCallResult(result, err), nil
+}
+
+func imageHistory(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	imageName, err := params.RequiredString("imageName")
 	if err != nil {
 		return api.NewToolCallResult("", err), nil
 	}
-	imageName := params.GetString("imageName", "")
-	result, err := params.Podman.ImageBuild(containerFile, imageName)
+	result, err := params.Podman.ImageHistory(imageName)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func imageInspect(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	imageName, err := params.RequiredString("imageName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ImageInspect(imageName)
 	return api.NewToolCallResult(result, err), nil
 }
 
@@ -143,6 +456,11 @@ func imageList(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallRe
 	return api.NewToolCallResult(result, err), nil
 }
 
+func imagePrune(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	result, err := params.Podman.ImagePrune(params.GetBool("all", false), pruneFilters(params), !params.GetBool("confirm", false))
+	return api.NewToolCallResult(result, err), nil
+}
+
 func imagePull(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	imageName, err := params.RequiredString("imageName")
 	if err != nil {
@@ -161,6 +479,18 @@ func imagePush(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallRe
 	return api.NewToolCallResult(result, err), nil
 }
 
+func imageLoad(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	path, err := params.RequiredString("path")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if path, err = params.Config.ResolveHostPath(path); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ImageLoad(path)
+	return api.NewToolCallResult(result, err), nil
+}
+
 func imageRemove(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	imageName, err := params.RequiredString("imageName")
 	if err != nil {
@@ -169,3 +499,43 @@ func imageRemove(_ context.Context, params api.ToolHandlerParams) (*api.ToolCall
 	result, err := params.Podman.ImageRemove(imageName)
 	return api.NewToolCallResult(result, err), nil
 }
+
+func imageSave(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	images, err := params.RequiredStringArray("images")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	path, err := params.RequiredString("path")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if path, err = params.Config.ResolveHostPath(path); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if err = params.Podman.ImageSave(images, path, params.GetString("format", "")); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Saved %s to %s", strings.Join(images, ", "), path), nil), nil
+}
+
+func imageTag(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	imageName, err := params.RequiredString("imageName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	targetName, err := params.RequiredString("targetName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ImageTag(imageName, targetName)
+	return api.NewToolCallResult(result, err), nil
+}
+
+func imageUntag(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	imageName, err := params.RequiredString("imageName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.ImageUntag(imageName, params.GetString("tag", ""))
+	return api.NewToolCallResult(result, err), nil
+}
@@ -0,0 +1,264 @@
+package mcp_test
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/manusa/podman-mcp-server/internal/test"
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// ImageArchiveSuite tests the image save and load tools with an allowed host directory configured.
+type ImageArchiveSuite struct {
+	test.McpSuite
+}
+
+func TestImageArchiveSuiteWithAllImplementations(t *testing.T) {
+	for _, impl := range test.AvailableImplementations() {
+		t.Run(impl, func(t *testing.T) {
+			suite.Run(t, &ImageArchiveSuite{
+				McpSuite: test.McpSuite{Config: config.Config{
+					PodmanImpl:      impl,
+					AllowedHostDirs: []string{t.TempDir()},
+				}},
+			})
+		})
+	}
+}
+
+// allowedDir returns the (symlink resolved) allowed host directory.
+func (s *ImageArchiveSuite) allowedDir() string {
+	dir, err := filepath.EvalSymlinks(s.Config.AllowedHostDirs[0])
+	s.Require().NoError(err)
+	return dir
+}
+
+func (s *ImageArchiveSuite) TestImageSave() {
+	s.WithImageExport(map[string]string{
+		"oci-layout":   `{"imageLayoutVersion":"1.0.0"}`,
+		"index.json":   `{"schemaVersion":2}`,
+		"blobs/sha256": "layer",
+	})
+
+	s.Run("image_save(images=nil) returns error", func() {
+		toolResult, err := s.CallTool("image_save", map[string]interface{}{
+			"path": filepath.Join(s.allowedDir(), "images.tar"),
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "images", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("image_save(multiple images) saves a docker-archive", func() {
+		s.MockServer.ClearRequests()
+		path := filepath.Join(s.allowedDir(), "images.tar")
+
+		toolResult, err := s.CallTool("image_save", map[string]interface{}{
+			"images": []interface{}{"myapp:1.2.3", "redis:7"},
+			"path":   path,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns save message", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Saved myapp:1.2.3, redis:7 to "+path, text)
+		})
+
+		s.Run("exports the images in the docker-archive format", func() {
+			req := s.MockServer.GetRequest("GET", "/libpod/images/export")
+			s.Require().NotNil(req, "export request should be made")
+			query, err := url.ParseQuery(req.Query)
+			s.Require().NoError(err)
+			s.Equal("docker-archive", query.Get("format"))
+			s.Equal([]string{"myapp:1.2.3", "redis:7"}, query["references"])
+		})
+
+		s.Run("writes the archive", func() {
+			content, err := os.ReadFile(path)
+			s.Require().NoError(err)
+			s.Equal(`{"schemaVersion":2}`, archiveFiles(s.T(), string(content))["index.json"])
+		})
+	})
+
+	s.Run("image_save(format=oci-dir) saves the image into a directory", func() {
+		s.MockServer.ClearRequests()
+		path := filepath.Join(s.allowedDir(), "myapp")
+
+		toolResult, err := s.CallTool("image_save", map[string]interface{}{
+			"images": []interface{}{"myapp:1.2.3"},
+			"path":   path,
+			"format": "oci-dir",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("exports the image in the oci-dir format", func() {
+			req := s.MockServer.GetRequest("GET", "/libpod/images/export")
+			s.Require().NotNil(req, "export request should be made")
+			s.Contains(req.Query, "format=oci-dir")
+		})
+
+		s.Run("writes the directory files", func() {
+			content, err := os.ReadFile(filepath.Join(path, "oci-layout"))
+			s.Require().NoError(err)
+			s.Equal(`{"imageLayoutVersion":"1.0.0"}`, string(content))
+			content, err = os.ReadFile(filepath.Join(path, "blobs", "sha256"))
+			s.Require().NoError(err)
+			s.Equal("layer", string(content))
+		})
+	})
+
+	s.Run("image_save(format=oci-archive, multiple images) returns error", func() {
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("image_save", map[string]interface{}{
+			"images": []interface{}{"myapp:1.2.3", "redis:7"},
+			"path":   filepath.Join(s.allowedDir(), "images-oci.tar"),
+			"format": "oci-archive",
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("the oci-archive format doesn't support multiple images, use docker-archive instead", text)
+		})
+
+		s.Run("does not export the images", func() {
+			s.False(s.MockServer.HasRequest("GET", "/libpod/images/export"))
+		})
+	})
+
+	s.Run("image_save(format=unsupported) returns error", func() {
+		toolResult, err := s.CallTool("image_save", map[string]interface{}{
+			"images": []interface{}{"myapp:1.2.3"},
+			"path":   filepath.Join(s.allowedDir(), "myapp.tar"),
+			"format": "tar",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "unsupported image archive format tar")
+	})
+
+	s.Run("image_save(path outside allowed dirs) returns error", func() {
+		s.MockServer.ClearRequests()
+		path := filepath.Join(s.T().TempDir(), "myapp.tar")
+
+		toolResult, err := s.CallTool("image_save", map[string]interface{}{
+			"images": []interface{}{"myapp:1.2.3"},
+			"path":   path,
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "is not located in any of the allowed host directories")
+		})
+
+		s.Run("does not save the images", func() {
+			s.False(s.MockServer.HasRequest("GET", "/libpod/images/export"))
+			s.NoFileExists(path)
+		})
+	})
+}
+
+func (s *ImageArchiveSuite) TestImageLoad() {
+	s.WithImageLoad("localhost/myapp:1.2.3", "docker.io/library/redis:7")
+
+	s.Run("image_load(path=nil) returns error", func() {
+		toolResult, err := s.CallTool("image_load", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "path", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("image_load(archive) loads the images", func() {
+		s.MockServer.ClearRequests()
+		path := filepath.Join(s.allowedDir(), "images.tar")
+		s.Require().NoError(os.WriteFile(path, []byte("archive content"), 0o644))
+
+		toolResult, err := s.CallTool("image_load", map[string]interface{}{
+			"path": path,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the loaded images", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Loaded image: localhost/myapp:1.2.3\nLoaded image: docker.io/library/redis:7", text)
+		})
+
+		s.Run("uploads the archive", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/images/load")
+			s.Require().NotNil(req, "load request should be made")
+			s.Equal("archive content", req.Body)
+		})
+	})
+
+	s.Run("image_load(oci-dir) uploads the directory as an archive", func() {
+		s.MockServer.ClearRequests()
+		path := filepath.Join(s.allowedDir(), "myapp")
+		s.Require().NoError(os.MkdirAll(filepath.Join(path, "blobs"), 0o755))
+		s.Require().NoError(os.WriteFile(filepath.Join(path, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0o644))
+		s.Require().NoError(os.WriteFile(filepath.Join(path, "blobs", "layer"), []byte("layer"), 0o644))
+
+		toolResult, err := s.CallTool("image_load", map[string]interface{}{
+			"path": path,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("uploads the directory files", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/images/load")
+			s.Require().NotNil(req, "load request should be made")
+			files := archiveFiles(s.T(), req.Body)
+			s.Equal(`{"imageLayoutVersion":"1.0.0"}`, files["oci-layout"])
+			s.Equal("layer", files["blobs/layer"])
+		})
+	})
+
+	s.Run("image_load(path outside allowed dirs) returns error", func() {
+		s.MockServer.ClearRequests()
+		path := filepath.Join(s.T().TempDir(), "images.tar")
+		s.Require().NoError(os.WriteFile(path, []byte("archive content"), 0o644))
+
+		toolResult, err := s.CallTool("image_load", map[string]interface{}{
+			"path": path,
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "is not located in any of the allowed host directories")
+		})
+
+		s.Run("does not load the images", func() {
+			s.False(s.MockServer.HasRequest("POST", "/libpod/images/load"))
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package mcp_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/manusa/podman-mcp-server/internal/test"
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// ImageBuildHostDirSuite tests the image builds from host paths with an allowed host directory configured.
+type ImageBuildHostDirSuite struct {
+	test.McpSuite
+	containerFile string
+}
+
+func TestImageBuildHostDirSuiteWithAllImplementations(t *testing.T) {
+	for _, impl := range test.AvailableImplementations() {
+		t.Run(impl, func(t *testing.T) {
+			suite.Run(t, &ImageBuildHostDirSuite{
+				McpSuite: test.McpSuite{Config: config.Config{
+					PodmanImpl:      impl,
+					AllowedHostDirs: []string{t.TempDir()},
+				}},
+			})
+		})
+	}
+}
+
+func (s *ImageBuildHostDirSuite) SetupTest() {
+	s.McpSuite.SetupTest()
+	// Create a Containerfile in the allowed host directory
+	s.containerFile = filepath.Join(s.Config.AllowedHostDirs[0], "Containerfile")
+	s.Require().NoError(os.WriteFile(s.containerFile, []byte("FROM alpine:latest\nCOPY . /app\n"), 0o644))
+}
+
+func (s *ImageBuildHostDirSuite) TestImageBuild() {
+	s.Run("image_build(containerFile, contextDir in allowed dir) builds the image", func() {
+		s.WithImageBuild("a1b2c3d4e5f6")
+		contextDir := filepath.Join(s.Config.AllowedHostDirs[0], "context")
+		s.Require().NoError(os.MkdirAll(contextDir, 0o755))
+		s.Require().NoError(os.WriteFile(filepath.Join(contextDir, "app.txt"), []byte("hello"), 0o644))
+
+		toolResult, err := s.CallTool("image_build", map[string]interface{}{
+			"containerFile": s.containerFile,
+			"contextDir":    contextDir,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("sends the context directory", func() {
+			req := s.PopLastCapturedRequest("POST", "/libpod/build")
+			s.Require().NotNil(req, "build request should be captured")
+			s.Equal("hello", buildContextFiles(s.T(), req.Body)["app.txt"])
+		})
+	})
+
+	s.Run("image_build(contextDir outside allowed dirs) returns error", func() {
+		s.MockServer.ClearRequests()
+
+		toolResult, err := s.CallTool("image_build", map[string]interface{}{
+			"containerFile": s.containerFile,
+			"contextDir":    "/etc",
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "host path /etc is not located in any of the allowed host directories")
+		})
+
+		s.Run("does not build the image", func() {
+			s.False(s.MockServer.HasRequest("POST", "/libpod/build"))
+		})
+	})
+
+	s.Run("image_build(containerFile outside allowed dirs) returns error", func() {
+		s.MockServer.ClearRequests()
+		containerFile := test.CreateTempFile(s.T(), "Containerfile", "FROM alpine:latest\nCOPY . /host\n")
+
+		toolResult, err := s.CallTool("image_build", map[string]interface{}{
+			"containerFile": containerFile,
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "is not located in any of the allowed host directories")
+		})
+
+		s.Run("does not build the image", func() {
+			s.False(s.MockServer.HasRequest("POST", "/libpod/build"))
+		})
+	})
+}
@@ -1,12 +1,24 @@
 package mcp_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/manusa/podman-mcp-server/internal/test"
@@ -177,6 +189,15 @@ func (s *ImageSuite) SetupTest() {
 	s.containerFile = test.CreateTempFile(s.T(), "Containerfile", "FROM alpine:latest\nRUN echo 'test'\n")
 }
 
+// buildContextFiles returns the name and content of the files in the build context sent as a gzip compressed tar archive.
+func buildContextFiles(t *testing.T, body string) map[string]string {
+	gr, err := gzip.NewReader(strings.NewReader(body))
+	require.NoError(t, err)
+	archive, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	return archiveFiles(t, string(archive))
+}
+
 func (s *ImageSuite) TestImagePull() {
 	s.Run("image_pull(imageName=nil) returns error", func() {
 		toolResult, err := s.CallTool("image_pull", map[string]interface{}{})
@@ -257,6 +278,271 @@ func (s *ImageSuite) TestImagePull() {
 	})
 }
 
+func (s *ImageSuite) TestImageInspect() {
+	s.Run("image_inspect(imageName=nil) returns error", func() {
+		toolResult, err := s.CallTool("image_inspect", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "imageName", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("image_inspect(imageName=nginx) returns image configuration", func() {
+		s.WithImageInspect(test.ImageInspectResponse{
+			ID:           "sha256:abc123def456",
+			RepoTags:     []string{"docker.io/library/nginx:latest"},
+			Created:      "2024-01-01T00:00:00Z",
+			Architecture: "arm64",
+			Os:           "linux",
+			Config: &test.ImageConfig{
+				ExposedPorts: map[string]struct{}{"80/tcp": {}},
+				Env:          []string{"NGINX_VERSION=1.27.0"},
+				Entrypoint:   []string{"/docker-entrypoint.sh"},
+				Cmd:          []string{"nginx", "-g", "daemon off;"},
+				Labels:       map[string]string{"maintainer": "NGINX Docker Maintainers"},
+			},
+		})
+
+		toolResult, err := s.CallTool("image_inspect", map[string]interface{}{
+			"imageName": "nginx",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns image inspect data", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "abc123def456")
+			s.Contains(text, "arm64")
+			s.Contains(text, "80/tcp")
+			s.Contains(text, "NGINX_VERSION=1.27.0")
+			s.Contains(text, "/docker-entrypoint.sh")
+			s.Contains(text, "NGINX Docker Maintainers")
+		})
+
+		s.Run("mock server received image inspect request", func() {
+			s.True(s.MockServer.HasRequest("GET", "/libpod/images/nginx/json"))
+		})
+	})
+
+	s.Run("image_inspect(imageName=nonexistent) returns not found error", func() {
+		s.WithError("GET", "/libpod/images/{name}/json", "/images/{name}/json",
+			http.StatusNotFound, "no such image: nonexistent")
+
+		toolResult, err := s.CallTool("image_inspect", map[string]interface{}{
+			"imageName": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
+func (s *ImageSuite) TestImageHistory() {
+	s.Run("image_history(imageName=nil) returns error", func() {
+		toolResult, err := s.CallTool("image_history", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "imageName", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("image_history(imageName=app) returns the layers", func() {
+		s.WithImageHistory([]test.ImageHistoryResponse{
+			{
+				ID:        "sha256:abc123def456789",
+				Created:   1704153600,
+				CreatedBy: "/bin/sh -c #(nop) CMD [\"nginx\" \"-g\" \"daemon off;\"]",
+				Tags:      []string{"localhost/app:latest"},
+				Size:      0,
+			},
+			{
+				ID:        "<missing>",
+				Created:   1704067200,
+				CreatedBy: "/bin/sh -c apk add --no-cache nginx",
+				Size:      12500000,
+			},
+			{
+				ID:        "<missing>",
+				Created:   1704067200,
+				CreatedBy: "/bin/sh -c #(nop) ADD file:0123abcd in / ",
+				Size:      7800000,
+			},
+		})
+
+		toolResult, err := s.CallTool("image_history", map[string]interface{}{
+			"imageName": "app",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns a row per layer with its size and instruction", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			lines := strings.Split(text, "\n")
+			s.Require().Len(lines, 4)
+			s.Regexp(`^ID\s+CREATED\s+SIZE\s+CREATED BY$`, lines[0])
+			s.Regexp(`^abc123def456\s+2024-01-02T00:00:00Z\s+0 B\s+CMD \["nginx" "-g" "daemon off;"\]$`, lines[1])
+			s.Regexp(`^<missing>\s+2024-01-01T00:00:00Z\s+12\.5 MB\s+RUN apk add --no-cache nginx$`, lines[2])
+			s.Regexp(`^<missing>\s+2024-01-01T00:00:00Z\s+7\.8 MB\s+ADD file:0123abcd in /$`, lines[3])
+		})
+
+		s.Run("mock server received image history request", func() {
+			s.True(s.MockServer.HasRequest("GET", "/libpod/images/app/history"))
+		})
+	})
+
+	s.Run("image_history(imageName=nonexistent) returns not found error", func() {
+		s.WithError("GET", "/libpod/images/{name}/history", "/images/{name}/history",
+			http.StatusNotFound, "no such image: nonexistent")
+
+		toolResult, err := s.CallTool("image_history", map[string]interface{}{
+			"imageName": "nonexistent",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
+func (s *ImageSuite) TestImagePrune() {
+	s.WithImageList([]test.ImageListResponse{
+		{
+			ID:       "sha256:abc123def456789",
+			RepoTags: []string{"<none>:<none>"},
+			Created:  1704067200,
+			Size:     85000000,
+		},
+		{
+			ID:       "sha256:def456abc123789",
+			RepoTags: []string{"localhost/app:old"},
+			Created:  1704067200,
+			Size:     1200000000,
+		},
+	})
+	s.WithImagePrune([]test.PruneReport{
+		{ID: "abc123def456789", Size: 85000000},
+		{ID: "def456abc123789", Size: 1200000000},
+	})
+
+	// lastQuery returns the query parameters and filters of the last request
+	lastQuery := func(method, path string) (url.Values, map[string][]string) {
+		req := s.MockServer.GetRequest(method, path)
+		s.Require().NotNil(req, "%s %s request should be made", method, path)
+		query, err := url.ParseQuery(req.Query)
+		s.Require().NoError(err)
+		var filters map[string][]string
+		if raw := query.Get("filters"); raw != "" {
+			s.Require().NoError(json.Unmarshal([]byte(raw), &filters))
+		}
+		return query, filters
+	}
+
+	s.Run("image_prune() lists the dangling images that would be removed", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("image_prune", map[string]interface{}{})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the images and reclaimable space", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "The following images would be removed:")
+			s.Regexp(`(?m)^IMAGE ID\s+NAME\s+SIZE$`, text)
+			s.Regexp(`(?m)^abc123def456\s+<none>\s+85\.0 MB$`, text)
+			s.Regexp(`(?m)^def456abc123\s+localhost/app:old\s+1\.2 GB$`, text)
+			s.Contains(text, "Total reclaimable space: 1.3 GB")
+		})
+
+		s.Run("lists the unused dangling images", func() {
+			_, filters := lastQuery("GET", "/libpod/images/json")
+			s.Equal(map[string][]string{"containers": {"false"}, "dangling": {"true"}}, filters)
+		})
+
+		s.Run("doesn't remove the images", func() {
+			s.False(s.MockServer.HasRequest("POST", "/libpod/images/prune"))
+		})
+	})
+
+	s.Run("image_prune(all=true, label) lists all the unused images matching the filters", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("image_prune", map[string]interface{}{
+			"all":   true,
+			"label": []interface{}{"stage=build"},
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		_, filters := lastQuery("GET", "/libpod/images/json")
+		s.Equal(map[string][]string{"containers": {"false"}, "label": {"stage=build"}}, filters)
+	})
+
+	s.Run("image_prune(label=key!=value) excludes the images with the label", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("image_prune", map[string]interface{}{
+			"label": []interface{}{"stage!=release"},
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		_, filters := lastQuery("GET", "/libpod/images/json")
+		s.Equal(map[string][]string{"containers": {"false"}, "dangling": {"true"}, "label!": {"stage=release"}}, filters)
+	})
+
+	s.Run("image_prune(label=key!=value, confirm=true) sends the label! filter", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("image_prune", map[string]interface{}{
+			"label":   []interface{}{"stage=build", "keep!=true"},
+			"confirm": true,
+		})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		_, filters := lastQuery("POST", "/libpod/images/prune")
+		s.Equal(map[string][]string{"label": {"stage=build"}, "label!": {"keep=true"}}, filters)
+	})
+
+	s.Run("image_prune(all=true, confirm=true) removes the images", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("image_prune", map[string]interface{}{
+			"all":     true,
+			"until":   "240h",
+			"confirm": true,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the removed images and reclaimed space", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "Removed images:")
+			s.Regexp(`(?m)^abc123def456\s+<none>\s+85\.0 MB$`, text)
+			s.Regexp(`(?m)^def456abc123\s+localhost/app:old\s+1\.2 GB$`, text)
+			s.Contains(text, "Total reclaimed space: 1.3 GB")
+		})
+
+		s.Run("sends the prune options", func() {
+			query, filters := lastQuery("POST", "/libpod/images/prune")
+			s.Equal("true", query.Get("all"))
+			s.Equal(map[string][]string{"until": {"240h"}}, filters)
+		})
+	})
+
+	s.Run("image_prune(confirm=true) returns error when prune fails", func() {
+		s.WithError("POST", "/libpod/images/prune", "/images/prune", http.StatusInternalServerError, "prune failed")
+		toolResult, err := s.CallTool("image_prune", map[string]interface{}{
+			"confirm": true,
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
 func (s *ImageSuite) TestImagePush() {
 	s.Run("image_push(imageName=nil) returns error", func() {
 		toolResult, err := s.CallTool("image_push", map[string]interface{}{})
@@ -367,6 +653,126 @@ func (s *ImageSuite) TestImageRemove() {
 	})
 }
 
+func (s *ImageSuite) TestImageTag() {
+	s.WithImageTag()
+
+	s.Run("image_tag(targetName=nil) returns error", func() {
+		toolResult, err := s.CallTool("image_tag", map[string]interface{}{
+			"imageName": "myapp:dev",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "targetName", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("image_tag(imageName=myapp:dev, targetName=registry.local/myapp:1.2.3) tags the image", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("image_tag", map[string]interface{}{
+			"imageName":  "myapp:dev",
+			"targetName": "registry.local/myapp:1.2.3",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns tag message", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("myapp:dev tagged as registry.local/myapp:1.2.3", text)
+		})
+
+		s.Run("sends the repository and tag", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/images/myapp:dev/tag")
+			s.Require().NotNil(req, "image tag request should be made")
+			query, err := url.ParseQuery(req.Query)
+			s.Require().NoError(err)
+			s.Equal("registry.local/myapp", query.Get("repo"))
+			s.Equal("1.2.3", query.Get("tag"))
+		})
+	})
+
+	s.Run("image_tag(imageName=nonexistent) returns not found error", func() {
+		s.WithError("POST", "/libpod/images/{name}/tag", "/images/{name}/tag",
+			http.StatusNotFound, "no such image: nonexistent")
+
+		toolResult, err := s.CallTool("image_tag", map[string]interface{}{
+			"imageName":  "nonexistent",
+			"targetName": "registry.local/myapp:1.2.3",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+	})
+}
+
+func (s *ImageSuite) TestImageUntag() {
+	s.WithImageUntag()
+
+	s.Run("image_untag(imageName=nil) returns error", func() {
+		toolResult, err := s.CallTool("image_untag", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "imageName", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("image_untag(imageName=abc123, tag=myapp:dev) removes the tag", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("image_untag", map[string]interface{}{
+			"imageName": "abc123",
+			"tag":       "myapp:dev",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns untag message", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("myapp:dev removed from abc123", text)
+		})
+
+		s.Run("sends the repository and tag", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/images/abc123/untag")
+			s.Require().NotNil(req, "image untag request should be made")
+			query, err := url.ParseQuery(req.Query)
+			s.Require().NoError(err)
+			s.Equal("myapp", query.Get("repo"))
+			s.Equal("dev", query.Get("tag"))
+		})
+	})
+
+	s.Run("image_untag(imageName=abc123) removes all the tags", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("image_untag", map[string]interface{}{
+			"imageName": "abc123",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns untag message", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("All tags removed from abc123", text)
+		})
+
+		s.Run("doesn't send a repository or tag", func() {
+			req := s.MockServer.GetRequest("POST", "/libpod/images/abc123/untag")
+			s.Require().NotNil(req, "image untag request should be made")
+			query, err := url.ParseQuery(req.Query)
+			s.Require().NoError(err)
+			s.Empty(query.Get("repo"))
+			s.Empty(query.Get("tag"))
+		})
+	})
+}
+
 func (s *ImageSuite) TestImageBuild() {
 	s.Run("image_build(containerFile=nil) returns error", func() {
 		toolResult, err := s.CallTool("image_build", map[string]interface{}{})
@@ -422,4 +828,291 @@ func (s *ImageSuite) TestImageBuild() {
 			s.Contains(req.Query, "t=example.com", "should have tag query param")
 		})
 	})
+
+	s.Run("image_build(options) includes the build options", func() {
+		s.WithImageBuild("c3d4e5f6a7b8")
+
+		_, _ = s.CallTool("image_build", map[string]interface{}{
+			"containerFile": s.containerFile,
+			"imageName":     "myapp:dev",
+			"tags":          []interface{}{"registry.local/myapp:1.2.3"},
+			"buildArgs":     []interface{}{"VERSION=1.2.3", "DEBUG="},
+			"target":        "runtime",
+			"labels":        []interface{}{"org.opencontainers.image.version=1.2.3"},
+			"noCache":       true,
+			"pull":          true,
+		})
+
+		req := s.PopLastCapturedRequest("POST", "/libpod/build")
+		s.Require().NotNil(req, "build request should be captured")
+		query, err := url.ParseQuery(req.Query)
+		s.Require().NoError(err)
+
+		s.Run("build request includes all the tags", func() {
+			s.Equal([]string{"myapp:dev", "registry.local/myapp:1.2.3"}, query["t"])
+		})
+
+		s.Run("build request includes build args", func() {
+			var buildArgs map[string]string
+			s.Require().NoError(json.Unmarshal([]byte(query.Get("buildargs")), &buildArgs))
+			s.Equal(map[string]string{"VERSION": "1.2.3", "DEBUG": ""}, buildArgs)
+		})
+
+		s.Run("build request includes target", func() {
+			s.Equal("runtime", query.Get("target"))
+		})
+
+		s.Run("build request includes labels", func() {
+			var labels []string
+			s.Require().NoError(json.Unmarshal([]byte(query.Get("labels")), &labels))
+			s.Equal([]string{"org.opencontainers.image.version=1.2.3"}, labels)
+		})
+
+		s.Run("build request disables cache", func() {
+			s.Equal("1", query.Get("nocache"))
+		})
+
+		s.Run("build request always pulls", func() {
+			s.Equal("always", query.Get("pullpolicy"))
+		})
+	})
+
+	s.Run("image_build(contextDir) sends the context directory", func() {
+		s.WithImageBuild("d4e5f6a7b8c9")
+		contextDir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(contextDir, "app.txt"), []byte("hello"), 0o644))
+
+		_, _ = s.CallTool("image_build", map[string]interface{}{
+			"containerFile": s.containerFile,
+			"contextDir":    contextDir,
+		})
+
+		req := s.PopLastCapturedRequest("POST", "/libpod/build")
+		s.Require().NotNil(req, "build request should be captured")
+		s.Equal("hello", buildContextFiles(s.T(), req.Body)["app.txt"])
+	})
+
+	s.Run("image_build(containerFile, contextDir) without allowed host dirs builds from the host paths", func() {
+		s.Require().Empty(s.Config.AllowedHostDirs, "the server should not restrict the host access")
+		s.WithImageBuild("e5f6a7b8c9d0")
+		contextDir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(contextDir, "app.txt"), []byte("hello"), 0o644))
+
+		toolResult, err := s.CallTool("image_build", map[string]interface{}{
+			"containerFile": s.containerFile,
+			"contextDir":    contextDir,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("sends the context directory", func() {
+			req := s.PopLastCapturedRequest("POST", "/libpod/build")
+			s.Require().NotNil(req, "build request should be captured")
+			s.Equal("hello", buildContextFiles(s.T(), req.Body)["app.txt"])
+		})
+	})
+
+	s.Run("image_build(buildArgs=invalid) returns error", func() {
+		toolResult, err := s.CallTool("image_build", map[string]interface{}{
+			"containerFile": s.containerFile,
+			"buildArgs":     []interface{}{"VERSION"},
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid build arg VERSION")
+	})
+}
+
+func (s *ImageSuite) TestImageBuildInline() {
+	// buildDirs returns the temporary build directories left behind
+	buildDirs := func() []string {
+		dirs, err := filepath.Glob(filepath.Join(os.TempDir(), "podman-mcp-build-*"))
+		s.Require().NoError(err)
+		return dirs
+	}
+
+	s.Run("image_build(containerFileContent, contextFiles) builds from the inline content", func() {
+		s.WithImageBuild("e5f6a7b8c9d0")
+
+		_, _ = s.CallTool("image_build", map[string]interface{}{
+			"containerFileContent": "FROM alpine:latest\nCOPY . /app\n",
+			"contextFiles": map[string]interface{}{
+				"index.html":    "<h1>Hello</h1>",
+				"conf/app.yaml": "port: 8080",
+			},
+			"imageName": "myapp:dev",
+		})
+
+		req := s.PopLastCapturedRequest("POST", "/libpod/build")
+		s.Require().NotNil(req, "build request should be captured")
+		files := buildContextFiles(s.T(), req.Body)
+
+		s.Run("build context includes the context files", func() {
+			s.Equal("<h1>Hello</h1>", files["index.html"])
+			s.Equal("port: 8080", files["conf/app.yaml"])
+		})
+
+		s.Run("build context includes the Containerfile", func() {
+			query, err := url.ParseQuery(req.Query)
+			s.Require().NoError(err)
+			var containerFiles []string
+			s.Require().NoError(json.Unmarshal([]byte(query.Get("dockerfile")), &containerFiles))
+			s.Require().Len(containerFiles, 1)
+			s.Equal("FROM alpine:latest\nCOPY . /app\n", files[containerFiles[0]], "files: %v", files)
+		})
+
+		s.Run("removes the temporary build context", func() {
+			s.Empty(buildDirs())
+		})
+	})
+
+	s.Run("image_build(contextArchive, containerFile) builds from the uploaded archive", func() {
+		s.WithImageBuild("f6a7b8c9d0e1")
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for name, content := range map[string]string{
+			"Dockerfile": "FROM alpine:latest\nCOPY app.sh /app.sh\n",
+			"app.sh":     "#!/bin/sh\necho hello\n",
+		} {
+			s.Require().NoError(tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			s.Require().NoError(err)
+		}
+		s.Require().NoError(tw.Close())
+
+		_, _ = s.CallTool("image_build", map[string]interface{}{
+			"contextArchive": base64.StdEncoding.EncodeToString(buf.Bytes()),
+			"containerFile":  "Dockerfile",
+		})
+
+		req := s.PopLastCapturedRequest("POST", "/libpod/build")
+		s.Require().NotNil(req, "build request should be captured")
+		files := buildContextFiles(s.T(), req.Body)
+
+		s.Run("build context includes the archive files", func() {
+			s.Equal("#!/bin/sh\necho hello\n", files["app.sh"])
+			s.Equal("FROM alpine:latest\nCOPY app.sh /app.sh\n", files["Dockerfile"])
+		})
+
+		s.Run("builds the archive Containerfile", func() {
+			s.Contains(req.Query, "dockerfile=%5B%22Dockerfile%22%5D")
+		})
+
+		s.Run("removes the temporary build context", func() {
+			s.Empty(buildDirs())
+		})
+	})
+
+	s.Run("image_build(contextArchive=invalid) returns error", func() {
+		toolResult, err := s.CallTool("image_build", map[string]interface{}{
+			"contextArchive": "not base64!",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "contextArchive is not valid base64")
+	})
+
+	s.Run("image_build(contextFiles outside the build context) returns error", func() {
+		s.MockServer.ClearRequests()
+		toolResult, err := s.CallTool("image_build", map[string]interface{}{
+			"containerFileContent": "FROM alpine:latest\n",
+			"contextFiles": map[string]interface{}{
+				"../escape.txt": "escaped",
+			},
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "must be relative to the build context")
+		})
+
+		s.Run("doesn't build the image", func() {
+			s.False(s.MockServer.HasRequest("POST", "/libpod/build"))
+		})
+
+		s.Run("removes the temporary build context", func() {
+			s.Empty(buildDirs())
+		})
+	})
+
+	s.Run("image_build(containerFile, containerFileContent) returns error", func() {
+		toolResult, err := s.CallTool("image_build", map[string]interface{}{
+			"containerFile":        s.containerFile,
+			"containerFileContent": "FROM alpine:latest\n",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "can't be specified together")
+	})
+}
+
+func (s *ImageSuite) TestImageBuildProgress() {
+	s.Run("image_build streams the build output as progress notifications", func() {
+		s.WithImageBuildOutput("a7b8c9d0e1f2",
+			"STEP 1/2: FROM alpine:latest",
+			"STEP 2/2: RUN echo hello",
+			"hello",
+			"COMMIT myapp:dev",
+		)
+
+		toolResult, err := s.CallToolWithProgress("image_build", map[string]interface{}{
+			"containerFile": s.containerFile,
+			"imageName":     "myapp:dev",
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the build output and the image ID once", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("STEP 1/2: FROM alpine:latest\n"+
+				"STEP 2/2: RUN echo hello\n"+
+				"hello\n"+
+				"COMMIT myapp:dev\n"+
+				"Image ID: a7b8c9d0e1f2", text)
+		})
+
+		s.Run("sends the build output lines as progress notifications", func() {
+			s.Eventually(func() bool {
+				return len(s.ProgressMessages()) == 5
+			}, time.Second, 10*time.Millisecond)
+			s.Equal([]string{
+				"STEP 1/2: FROM alpine:latest",
+				"STEP 2/2: RUN echo hello",
+				"hello",
+				"COMMIT myapp:dev",
+				"a7b8c9d0e1f2",
+			}, s.ProgressMessages())
+		})
+	})
+
+	s.Run("image_build with a long build output returns the last lines", func() {
+		lines := make([]string, 30)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("output line %d", i+1)
+		}
+		s.WithImageBuildOutput("b8c9d0e1f2a3", lines...)
+
+		toolResult, err := s.CallTool("image_build", map[string]interface{}{
+			"containerFile": s.containerFile,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+
+		s.Run("notes the omitted lines", func() {
+			s.True(strings.HasPrefix(text, "... (10 earlier lines omitted)\noutput line 11\n"), text)
+			s.NotContains(text, "output line 10\n")
+		})
+
+		s.Run("ends with the image ID", func() {
+			s.True(strings.HasSuffix(text, "output line 30\nImage ID: b8c9d0e1f2a3"), text)
+		})
+	})
 }
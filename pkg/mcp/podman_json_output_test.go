@@ -137,6 +137,237 @@ func (s *JSONOutputSuite) TestContainerListErrorJSON() {
 	})
 }
 
+func (s *JSONOutputSuite) TestContainerStatsJSON() {
+	s.WithContainerStats([]test.ContainerStatsResponse{
+		{
+			ContainerID: "abc123def456",
+			Name:        "web",
+			CPU:         25,
+			MemUsage:    100_000_000,
+			MemLimit:    1_000_000_000,
+			MemPerc:     10,
+			PIDs:        3,
+		},
+	})
+
+	toolResult, err := s.CallTool("container_stats", map[string]interface{}{})
+
+	s.Run("returns OK", func() {
+		s.NoError(err)
+		s.False(toolResult.IsError)
+	})
+
+	s.Run("returns valid JSON array", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+
+		var stats []map[string]interface{}
+		s.Require().NoError(json.Unmarshal([]byte(text), &stats), "output should be valid JSON array")
+		s.Require().Len(stats, 1)
+		s.Equal("abc123def456", stats[0]["id"])
+		s.Equal("web", stats[0]["name"])
+		s.Equal(25.0, stats[0]["cpuPercent"])
+		s.Equal(100_000_000.0, stats[0]["memUsage"])
+	})
+}
+
+func (s *JSONOutputSuite) TestContainerTopJSON() {
+	s.WithContainerTop(test.ContainerTopResponse{
+		Titles: []string{"PID", "USER", "COMMAND"},
+		Processes: [][]string{
+			{"1", "root", "nginx: master process nginx -g daemon off;"},
+		},
+	})
+
+	toolResult, err := s.CallTool("container_top", map[string]interface{}{
+		"name": "web",
+	})
+
+	s.Run("returns OK", func() {
+		s.NoError(err)
+		s.False(toolResult.IsError)
+	})
+
+	s.Run("returns valid JSON array keyed by title", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+
+		var processes []map[string]string
+		s.Require().NoError(json.Unmarshal([]byte(text), &processes), "output should be valid JSON array")
+		s.Require().Len(processes, 1)
+		s.Equal("1", processes[0]["PID"])
+		s.Equal("root", processes[0]["USER"])
+		s.Equal("nginx: master process nginx -g daemon off;", processes[0]["COMMAND"])
+	})
+}
+
+func (s *JSONOutputSuite) TestContainerDiffJSON() {
+	s.WithContainerDiff([]test.ContainerChange{
+		{Path: "/etc", Kind: 0},
+		{Path: "/etc/app.conf", Kind: 1},
+		{Path: "/tmp/cache", Kind: 2},
+	})
+
+	toolResult, err := s.CallTool("container_diff", map[string]interface{}{
+		"name": "web",
+	})
+
+	s.Run("returns OK", func() {
+		s.NoError(err)
+		s.False(toolResult.IsError)
+	})
+
+	s.Run("returns valid JSON grouped by kind", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+
+		var changes map[string][]string
+		s.Require().NoError(json.Unmarshal([]byte(text), &changes), "output should be valid JSON object")
+		s.Equal([]string{"/etc"}, changes["changed"])
+		s.Equal([]string{"/etc/app.conf"}, changes["added"])
+		s.Equal([]string{"/tmp/cache"}, changes["deleted"])
+	})
+}
+
+func (s *JSONOutputSuite) TestContainerFileListJSON() {
+	s.WithContainerArchive(map[string]string{
+		"/etc/nginx/nginx.conf":      "worker_processes 1;",
+		"/etc/nginx/conf.d/app.conf": "server {}",
+	})
+
+	toolResult, err := s.CallTool("container_file_list", map[string]interface{}{
+		"name": "web",
+		"path": "/etc/nginx",
+	})
+
+	s.Run("returns OK", func() {
+		s.NoError(err)
+		s.False(toolResult.IsError)
+	})
+
+	s.Run("returns valid JSON array with an object per entry", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+
+		var files []map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(text), &files), "output should be valid JSON array")
+		s.Require().Len(files, 2)
+		s.Equal("conf.d", files[0]["name"])
+		s.Equal(true, files[0]["isDir"])
+		s.Equal("drwxr-xr-x", files[0]["mode"])
+		s.Equal("nginx.conf", files[1]["name"])
+		s.Equal(float64(19), files[1]["size"])
+		s.Equal("-rw-r--r--", files[1]["mode"])
+		s.Equal("2024-01-01T00:00:00Z", files[1]["modTime"])
+	})
+}
+
+func (s *JSONOutputSuite) TestContainerHealthJSON() {
+	s.WithContainerInspect(test.ContainerInspectResponse{
+		ID:      "abc123def456",
+		Name:    "/web",
+		Created: "2024-01-01T00:00:00Z",
+		State: &test.ContainerState{
+			Status:  "running",
+			Running: true,
+			Health: &test.HealthState{
+				Status:        "healthy",
+				FailingStreak: 0,
+				Log: []test.HealthLog{
+					{Start: "2024-01-01T00:00:00Z", End: "2024-01-01T00:00:01Z", ExitCode: 0, Output: "ok"},
+				},
+			},
+		},
+	})
+
+	toolResult, err := s.CallTool("container_health", map[string]interface{}{
+		"name": "web",
+	})
+
+	s.Run("returns OK", func() {
+		s.NoError(err)
+		s.False(toolResult.IsError)
+	})
+
+	s.Run("returns valid JSON object with status and probe log", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+
+		var health map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(text), &health), "output should be valid JSON object")
+		s.Equal("healthy", health["status"])
+		s.Equal(float64(0), health["failingStreak"])
+		s.Require().Len(health["log"], 1)
+		probe := health["log"].([]any)[0].(map[string]any)
+		s.Equal("2024-01-01T00:00:00Z", probe["start"])
+		s.Equal(float64(0), probe["exitCode"])
+		s.Equal("ok", probe["output"])
+	})
+}
+
+func (s *JSONOutputSuite) TestContainerPruneJSON() {
+	s.WithContainerList([]test.ContainerListResponse{
+		{
+			ID:      "abc123def456789",
+			Names:   []string{"old-job"},
+			Image:   "docker.io/library/alpine:latest",
+			Created: "2024-01-01T00:00:00Z",
+			State:   "exited",
+			Size:    &test.ContainerSize{RwSize: 1500000},
+		},
+	})
+
+	toolResult, err := s.CallTool("container_prune", map[string]interface{}{})
+
+	s.Run("returns OK", func() {
+		s.NoError(err)
+		s.False(toolResult.IsError)
+	})
+
+	s.Run("returns valid JSON object with the containers that would be removed", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+
+		var report map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(text), &report), "output should be valid JSON object")
+		s.Equal(true, report["dryRun"])
+		s.Equal(float64(1500000), report["totalSize"])
+		s.Require().Len(report["resources"], 1)
+		container := report["resources"].([]any)[0].(map[string]any)
+		s.Equal("abc123def456789", container["id"])
+		s.Equal("old-job", container["name"])
+		s.Equal(float64(1500000), container["size"])
+	})
+}
+
+func (s *JSONOutputSuite) TestImageHistoryJSON() {
+	s.WithImageHistory([]test.ImageHistoryResponse{
+		{
+			ID:        "sha256:abc123def456789",
+			Created:   1704067200,
+			CreatedBy: "/bin/sh -c apk add --no-cache nginx",
+			Tags:      []string{"localhost/app:latest"},
+			Size:      12500000,
+		},
+	})
+
+	toolResult, err := s.CallTool("image_history", map[string]interface{}{
+		"imageName": "app",
+	})
+
+	s.Run("returns OK", func() {
+		s.NoError(err)
+		s.False(toolResult.IsError)
+	})
+
+	s.Run("returns valid JSON array with an object per layer", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+
+		var layers []map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(text), &layers), "output should be valid JSON array")
+		s.Require().Len(layers, 1)
+		s.Equal("abc123def456789", layers[0]["id"])
+		s.Equal("2024-01-01T00:00:00Z", layers[0]["created"])
+		s.Equal("RUN apk add --no-cache nginx", layers[0]["createdBy"])
+		s.Equal([]any{"localhost/app:latest"}, layers[0]["tags"])
+		s.Equal(float64(12500000), layers[0]["size"])
+	})
+}
+
 func (s *JSONOutputSuite) TestImageListJSON() {
 	s.WithImageList([]test.ImageListResponse{
 		{
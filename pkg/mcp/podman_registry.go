@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manusa/podman-mcp-server/pkg/api"
+	"github.com/manusa/podman-mcp-server/pkg/podman"
+)
+
+func initRegistryTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "registry_list_logins",
+				Description: "Lists the container registries with stored login credentials (in the server auth file or credential helpers) and their usernames. The passwords and tokens are never shown",
+				Annotations: api.ToolAnnotations{
+					Title:           "Registry: List Logins",
+					ReadOnlyHint:    ptr(true),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+				},
+			},
+			Handler: registryListLogins,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "registry_login",
+				Description: "Logs in to a container registry, verifying the credentials and storing them in the server auth file (--authfile) so that image_pull and image_push can access private repositories",
+				Annotations: api.ToolAnnotations{
+					Title:           "Registry: Login",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(false),
+					IdempotentHint:  ptr(true),
+					OpenWorldHint:   ptr(true),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"registry": {
+							Type:        "string",
+							Description: "Registry host (and port) to log in to, optionally with a repository path to store the credentials for. Example: quay.io, localhost:5000 or ghcr.io/my-org",
+						},
+						"username": {
+							Type:        "string",
+							Description: "Registry account username",
+						},
+						"password": {
+							Type:        "string",
+							Description: "Registry account password or access token",
+						},
+						"tlsVerify": {
+							Type:        "boolean",
+							Description: "Require HTTPS and verify the registry certificate, set to false for local registries served over plain HTTP or with self-signed certificates. (Optional, default true)",
+						},
+					},
+					Required: []string{"registry", "username", "password"},
+				},
+			},
+			Handler: registryLogin,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "registry_logout",
+				Description: "Logs out of a container registry, removing its credentials from the server auth file (--authfile)",
+				Annotations: api.ToolAnnotations{
+					Title:           "Registry: Logout",
+					ReadOnlyHint:    ptr(false),
+					DestructiveHint: ptr(true),
+					IdempotentHint:  ptr(false),
+					OpenWorldHint:   ptr(false),
+				},
+				InputSchema: api.InputSchema{
+					Type: "object",
+					Properties: map[string]api.Property{
+						"registry": {
+							Type:        "string",
+							Description: "Registry host (and port) to log out of, as used to log in. Example: quay.io. (Required unless all is true)",
+						},
+						"all": {
+							Type:        "boolean",
+							Description: "Remove the credentials of all the registries. (Optional, default false)",
+						},
+					},
+				},
+			},
+			Handler: registryLogout,
+		},
+	}
+}
+
+func registryListLogins(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	result, err := params.Podman.RegistryListLogins()
+	return api.NewToolCallResult(result, err), nil
+}
+
+func registryLogin(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	registry, err := params.RequiredString("registry")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	username, err := params.RequiredString("username")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	password, err := params.RequiredString("password")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	result, err := params.Podman.RegistryLogin(registry, podman.RegistryLoginOptions{
+		Username:      username,
+		Password:      password,
+		SkipTLSVerify: !params.GetBool("tlsVerify", true),
+	})
+	return api.NewToolCallResult(result, err), nil
+}
+
+func registryLogout(_ context.Context, params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	registry := params.GetString("registry", "")
+	all := params.GetBool("all", false)
+	if registry == "" && !all {
+		return api.NewToolCallResult("", errors.New("registry parameter required, or all to log out of all the registries")), nil
+	}
+	result, err := params.Podman.RegistryLogout(registry, all)
+	return api.NewToolCallResult(result, err), nil
+}
@@ -0,0 +1,282 @@
+package mcp_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/manusa/podman-mcp-server/internal/test"
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// RegistrySuite tests the registry tools, and the registry credentials used by the image tools,
+// with an auth file configured and a registry stand-in to log in to.
+type RegistrySuite struct {
+	test.McpSuite
+	registry *test.MockRegistry
+}
+
+func TestRegistrySuiteWithAllImplementations(t *testing.T) {
+	for _, impl := range test.AvailableImplementations() {
+		t.Run(impl, func(t *testing.T) {
+			suite.Run(t, &RegistrySuite{
+				McpSuite: test.McpSuite{Config: config.Config{
+					PodmanImpl: impl,
+					AuthFile:   filepath.Join(t.TempDir(), "auth.json"),
+				}},
+			})
+		})
+	}
+}
+
+func (s *RegistrySuite) SetupTest() {
+	s.McpSuite.SetupTest()
+	s.Require().NoError(os.RemoveAll(s.Config.AuthFile))
+	s.registry = test.NewMockRegistry("developer", "s3cr3t")
+}
+
+func (s *RegistrySuite) TearDownTest() {
+	if s.registry != nil {
+		s.registry.Close()
+		s.registry = nil
+	}
+	s.McpSuite.TearDownTest()
+}
+
+// login logs in to the registry stand-in with valid credentials.
+func (s *RegistrySuite) login() {
+	toolResult, err := s.CallTool("registry_login", map[string]interface{}{
+		"registry":  s.registry.Host(),
+		"username":  "developer",
+		"password":  "s3cr3t",
+		"tlsVerify": false,
+	})
+	s.Require().NoError(err)
+	s.Require().False(toolResult.IsError, "login should succeed")
+}
+
+// authFileAuths returns the registries (to base64 encoded credentials) stored in the auth file.
+func (s *RegistrySuite) authFileAuths() map[string]string {
+	content, err := os.ReadFile(s.Config.AuthFile)
+	if os.IsNotExist(err) {
+		return map[string]string{}
+	}
+	s.Require().NoError(err)
+	var authFile struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	s.Require().NoError(json.Unmarshal(content, &authFile))
+	auths := make(map[string]string)
+	for registry, auth := range authFile.Auths {
+		auths[registry] = auth.Auth
+	}
+	return auths
+}
+
+// registryAuthHeader decodes the registry credentials (registry to username) sent by the client in the request.
+func (s *RegistrySuite) registryAuthHeader(req *test.CapturedRequest) map[string]string {
+	header := req.Header.Get("X-Registry-Auth")
+	s.Require().NotEmpty(header, "request should include the registry credentials")
+	decoded, err := base64.URLEncoding.DecodeString(header)
+	s.Require().NoError(err)
+	var auths map[string]struct {
+		Username string `json:"username"`
+	}
+	s.Require().NoError(json.Unmarshal(decoded, &auths))
+	usernames := make(map[string]string)
+	for registry, auth := range auths {
+		usernames[registry] = auth.Username
+	}
+	return usernames
+}
+
+func (s *RegistrySuite) TestRegistryLogin() {
+	s.Run("registry_login(password=nil) returns error", func() {
+		toolResult, err := s.CallTool("registry_login", map[string]interface{}{
+			"registry": s.registry.Host(),
+			"username": "developer",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "password", "error should mention the missing parameter")
+		s.Contains(text, "required", "error should indicate parameter is required")
+	})
+
+	s.Run("registry_login(invalid credentials) returns error", func() {
+		toolResult, err := s.CallTool("registry_login", map[string]interface{}{
+			"registry":  s.registry.Host(),
+			"username":  "developer",
+			"password":  "wrong",
+			"tlsVerify": false,
+		})
+
+		s.Run("returns error", func() {
+			s.NoError(err)
+			s.True(toolResult.IsError, "tool result should indicate an error")
+		})
+
+		s.Run("does not store the credentials", func() {
+			s.NotContains(s.authFileAuths(), s.registry.Host())
+		})
+	})
+
+	s.Run("registry_login(valid credentials) logs in", func() {
+		toolResult, err := s.CallTool("registry_login", map[string]interface{}{
+			"registry":  s.registry.Host(),
+			"username":  "developer",
+			"password":  "s3cr3t",
+			"tlsVerify": false,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns login message", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Login Succeeded!", text)
+		})
+
+		s.Run("stores the credentials in the auth file", func() {
+			s.Equal(base64.StdEncoding.EncodeToString([]byte("developer:s3cr3t")), s.authFileAuths()[s.registry.Host()])
+		})
+	})
+}
+
+func (s *RegistrySuite) TestRegistryListLogins() {
+	s.Run("registry_list_logins() without logins returns empty message", func() {
+		toolResult, err := s.CallTool("registry_list_logins", map[string]interface{}{})
+		s.NoError(err)
+		s.False(toolResult.IsError)
+		s.Equal("No registry logins found", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+
+	s.Run("registry_list_logins() lists the registries with stored credentials", func() {
+		s.login()
+
+		toolResult, err := s.CallTool("registry_list_logins", map[string]interface{}{})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns the registry and username", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Regexp(`^REGISTRY\s+USERNAME\n`+s.registry.Host()+`\s+developer$`, text)
+		})
+
+		s.Run("does not reveal the password", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.NotContains(text, "s3cr3t")
+			s.NotContains(text, base64.StdEncoding.EncodeToString([]byte("developer:s3cr3t")))
+		})
+	})
+}
+
+func (s *RegistrySuite) TestRegistryLogout() {
+	s.Run("registry_logout(registry=nil) returns error", func() {
+		toolResult, err := s.CallTool("registry_logout", map[string]interface{}{})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "registry parameter required")
+	})
+
+	s.Run("registry_logout(not logged in registry) returns error", func() {
+		s.login()
+
+		toolResult, err := s.CallTool("registry_logout", map[string]interface{}{
+			"registry": "quay.io",
+		})
+		s.NoError(err)
+		s.True(toolResult.IsError, "tool result should indicate an error")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "not logged into quay.io")
+	})
+
+	s.Run("registry_logout(registry) removes the credentials", func() {
+		s.login()
+
+		toolResult, err := s.CallTool("registry_logout", map[string]interface{}{
+			"registry": s.registry.Host(),
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns logout message", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Removed login credentials for "+s.registry.Host(), text)
+		})
+
+		s.Run("removes the credentials from the auth file", func() {
+			s.NotContains(s.authFileAuths(), s.registry.Host())
+		})
+	})
+
+	s.Run("registry_logout(all=true) removes all the credentials", func() {
+		s.login()
+
+		toolResult, err := s.CallTool("registry_logout", map[string]interface{}{
+			"all": true,
+		})
+
+		s.Run("returns OK", func() {
+			s.NoError(err)
+			s.False(toolResult.IsError)
+		})
+
+		s.Run("returns logout message", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Equal("Removed login credentials for all registries", text)
+		})
+
+		s.Run("removes the credentials from the auth file", func() {
+			s.Empty(s.authFileAuths())
+		})
+	})
+}
+
+func (s *RegistrySuite) TestImagePullPushWithAuthFile() {
+	s.login()
+	image := s.registry.Host() + "/team/myapp:1.0.0"
+
+	s.Run("image_pull sends the stored registry credentials", func() {
+		s.WithImagePull("abc123def456")
+
+		toolResult, err := s.CallTool("image_pull", map[string]interface{}{
+			"imageName": image,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError)
+
+		req := s.PopLastCapturedRequest("POST", "/libpod/images/pull")
+		s.Require().NotNil(req, "pull request should be captured")
+		s.Equal("developer", s.registryAuthHeader(req)[s.registry.Host()])
+	})
+
+	s.Run("image_push sends the stored registry credentials", func() {
+		s.WithImagePush()
+
+		toolResult, err := s.CallTool("image_push", map[string]interface{}{
+			"imageName": image,
+		})
+		s.Require().NoError(err)
+		s.Require().False(toolResult.IsError)
+
+		req := s.PopLastCapturedRequest("POST", "/libpod/images/{name}/push")
+		s.Require().NotNil(req, "push request should be captured")
+		s.Equal("developer", s.registryAuthHeader(req)[s.registry.Host()])
+	})
+}
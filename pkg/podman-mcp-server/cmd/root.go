@@ -54,8 +54,10 @@ Podman Model Context Protocol (MCP) server
 		}()
 
 		cfg := config.WithOverrides(config.Config{
-			PodmanImpl:   viper.GetString("podman-impl"),
-			OutputFormat: viper.GetString("output-format"),
+			PodmanImpl:      viper.GetString("podman-impl"),
+			OutputFormat:    viper.GetString("output-format"),
+			AllowedHostDirs: viper.GetStringSlice("allowed-host-dir"),
+			AuthFile:        viper.GetString("authfile"),
 		})
 		mcpServer, err := mcp.NewServer(cfg)
 		if err != nil {
@@ -108,6 +110,8 @@ func init() {
 	rootCmd.Flags().StringP("sse-base-url", "", "", "SSE public base URL to use when sending the endpoint message (e.g. https://example.com)")
 	rootCmd.Flags().StringP("podman-impl", "", "", "Podman implementation to use (available: "+strings.Join(podman.ImplementationNames(), ", ")+"). Auto-detects if not specified.")
 	rootCmd.Flags().StringP("output-format", "o", "", "Output format for list commands (text, json). Defaults to text.")
+	rootCmd.Flags().StringP("authfile", "", "", "Path of the registry authentication file used to log in to registries and to pull and push images. Defaults to the podman auth file.")
+	rootCmd.Flags().StringSliceP("allowed-host-dir", "", nil, "Host directory the tools are allowed to copy files from or to, or bind mount in containers (can be repeated). Host filesystem access is disabled if not specified.")
 	_ = rootCmd.Flags().MarkDeprecated("sse-port", "use --port instead")
 	_ = rootCmd.Flags().MarkDeprecated("sse-base-url", "use --port instead")
 	_ = viper.BindPFlags(rootCmd.Flags())
@@ -103,3 +103,33 @@ func (s *RootCmdSuite) TestHelpPodmanImplFlag() {
 		s.Regexp(`Auto-detects if not specified`, help)
 	})
 }
+
+func (s *RootCmdSuite) TestHelpAllowedHostDirFlag() {
+	help, err := s.captureOutput([]string{"--help"})
+	s.Require().NoError(err)
+
+	s.Run("contains allowed-host-dir flag", func() {
+		s.Regexp(`--allowed-host-dir strings`, help)
+	})
+
+	s.Run("contains flag description", func() {
+		s.Regexp(`--allowed-host-dir strings\s+Host directory the tools are allowed to copy files from or to`, help)
+	})
+
+	s.Run("mentions host access is disabled by default", func() {
+		s.Contains(help, "Host filesystem access is disabled if not specified")
+	})
+}
+
+func (s *RootCmdSuite) TestHelpAuthFileFlag() {
+	help, err := s.captureOutput([]string{"--help"})
+	s.Require().NoError(err)
+
+	s.Run("contains authfile flag", func() {
+		s.Regexp(`--authfile string`, help)
+	})
+
+	s.Run("contains flag description", func() {
+		s.Regexp(`--authfile string\s+Path of the registry authentication file used to log in to registries and to pull and push images`, help)
+	})
+}
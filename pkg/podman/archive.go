@@ -0,0 +1,73 @@
+//go:build !exclude_podman_api
+
+package podman
+
+import (
+	"archive/tar"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// hostCopyDestination returns the host directory where a copied file or directory is extracted,
+// and the name its archive root is renamed to (empty to keep the source name).
+// As in podman cp, the source is copied into the destination if it's an existing directory,
+// or copied as the destination otherwise.
+func hostCopyDestination(hostPath string) (string, string) {
+	if info, err := os.Stat(hostPath); err == nil && info.IsDir() {
+		return hostPath, ""
+	}
+	return filepath.Dir(hostPath), filepath.Base(hostPath)
+}
+
+// createArchive writes the host file or directory to w as a tar archive with a root entry named rootName.
+// Symlinks are archived as such and never followed.
+func createArchive(w io.Writer, hostPath string, rootName string) error {
+	tw := tar.NewWriter(w)
+	err := filepath.WalkDir(hostPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(hostPath, p)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(rootName, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
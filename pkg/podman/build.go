@@ -0,0 +1,191 @@
+package podman
+
+import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// prepareBuildContext writes the inline Containerfile content and the uploaded context files or archive of the options
+// into a temporary directory, returning the options pointing to the written files and a function to remove them.
+// Options without inline content are returned unchanged.
+func prepareBuildContext(opts ImageBuildOptions) (ImageBuildOptions, func(), error) {
+	uploadedContext := len(opts.ContextFiles) > 0 || len(opts.ContextArchive) > 0
+	if opts.ContainerFileContent == "" && !uploadedContext {
+		return opts, func() {}, nil
+	}
+	switch {
+	case opts.ContainerFileContent != "" && opts.ContainerFile != "":
+		return opts, nil, errors.New("the Containerfile path and content can't be specified together")
+	case len(opts.ContextFiles) > 0 && len(opts.ContextArchive) > 0:
+		return opts, nil, errors.New("the context files and context archive can't be specified together")
+	case uploadedContext && opts.ContextDir != "":
+		return opts, nil, errors.New("the context directory can't be specified together with the context files or archive")
+	case uploadedContext && opts.ContainerFile != "" && !filepath.IsLocal(opts.ContainerFile):
+		return opts, nil, fmt.Errorf("the Containerfile path %s must be relative to the uploaded build context", opts.ContainerFile)
+	}
+	dir, err := os.MkdirTemp("", "podman-mcp-build-")
+	if err != nil {
+		return opts, nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+	if err = writeBuildContext(dir, &opts); err != nil {
+		cleanup()
+		return opts, nil, err
+	}
+	return opts, cleanup, nil
+}
+
+// writeBuildContext writes the inline content of the options into dir and updates them to point to the written files.
+// The Containerfile content is written next to the context directory so that it can't overwrite any of its files.
+func writeBuildContext(dir string, opts *ImageBuildOptions) error {
+	contextDir := filepath.Join(dir, "context")
+	if err := os.Mkdir(contextDir, 0o755); err != nil {
+		return err
+	}
+	switch {
+	case len(opts.ContextFiles) > 0:
+		if err := writeContextFiles(contextDir, opts.ContextFiles); err != nil {
+			return err
+		}
+	case len(opts.ContextArchive) > 0:
+		if err := extractContextArchive(contextDir, opts.ContextArchive); err != nil {
+			return err
+		}
+	}
+	if len(opts.ContextFiles) > 0 || len(opts.ContextArchive) > 0 || opts.ContextDir == "" {
+		opts.ContextDir = contextDir
+	}
+	if opts.ContainerFileContent == "" {
+		opts.ContainerFile = filepath.Join(contextDir, cmp.Or(opts.ContainerFile, "Containerfile"))
+		return nil
+	}
+	opts.ContainerFile = filepath.Join(dir, "Containerfile")
+	return os.WriteFile(opts.ContainerFile, []byte(opts.ContainerFileContent), 0o644)
+}
+
+// writeContextFiles writes the files (relative path to content) into the context directory.
+func writeContextFiles(contextDir string, files map[string]string) error {
+	root, err := os.OpenRoot(contextDir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = root.Close() }()
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		filePath := filepath.FromSlash(name)
+		if !filepath.IsLocal(filePath) {
+			return fmt.Errorf("context file path %s must be relative to the build context", name)
+		}
+		if parent := filepath.Dir(filePath); parent != "." {
+			if err = root.MkdirAll(parent, 0o755); err != nil {
+				return err
+			}
+		}
+		if err = extractFile(root, filePath, 0o644, strings.NewReader(files[name])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractContextArchive extracts the tar archive, optionally gzip compressed, into the context directory.
+func extractContextArchive(contextDir string, archive []byte) error {
+	var r io.Reader = bytes.NewReader(archive)
+	if bytes.HasPrefix(archive, []byte{0x1f, 0x8b}) {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("failed to read the context archive: %w", err)
+		}
+		defer func() { _ = gr.Close() }()
+		r = gr
+	}
+	if err := extractArchive(r, contextDir, ""); err != nil {
+		return fmt.Errorf("failed to extract the context archive: %w", err)
+	}
+	return nil
+}
+
+// maxBuildLogLines is the number of trailing build output lines included in the build result.
+const maxBuildLogLines = 20
+
+// buildLog is an io.Writer that collects the output of a build, passing each non-empty line to onLine as soon as
+// it's complete. It's not safe for concurrent writes.
+type buildLog struct {
+	onLine  func(line string)
+	lines   []string
+	partial []byte
+}
+
+func newBuildLog(onLine func(line string)) *buildLog {
+	if onLine == nil {
+		onLine = func(string) {}
+	}
+	return &buildLog{onLine: onLine}
+}
+
+func (l *buildLog) Write(p []byte) (int, error) {
+	l.partial = append(l.partial, p...)
+	for {
+		i := bytes.IndexByte(l.partial, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		l.add(string(l.partial[:i]))
+		l.partial = l.partial[i+1:]
+	}
+}
+
+// flush passes the last line to onLine if the output didn't end with a newline.
+func (l *buildLog) flush() {
+	l.add(string(l.partial))
+	l.partial = nil
+}
+
+func (l *buildLog) add(line string) {
+	line = strings.TrimRight(line, "\r ")
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	l.lines = append(l.lines, line)
+	l.onLine(line)
+}
+
+// lastLine returns the last line of the build output, the image ID for a successful podman build.
+func (l *buildLog) lastLine() string {
+	if len(l.lines) == 0 {
+		return ""
+	}
+	return l.lines[len(l.lines)-1]
+}
+
+// tail returns the last maxBuildLogLines lines of the build output, noting how many earlier lines were omitted.
+func (l *buildLog) tail() string {
+	lines := l.lines
+	var buf strings.Builder
+	if omitted := len(lines) - maxBuildLogLines; omitted > 0 {
+		_, _ = fmt.Fprintf(&buf, "... (%d earlier lines omitted)\n", omitted)
+		lines = lines[omitted:]
+	}
+	buf.WriteString(strings.Join(lines, "\n"))
+	return buf.String()
+}
+
+// result returns the trimmed build output followed by the ID of the built image.
+// The last line of the output, podman's own report of the image ID, is replaced by the ID line.
+func (l *buildLog) result(imageID string) string {
+	if imageID != "" && l.lastLine() == imageID {
+		l.lines = l.lines[:len(l.lines)-1]
+	}
+	if len(l.lines) == 0 {
+		return "Image ID: " + imageID
+	}
+	return fmt.Sprintf("%s\nImage ID: %s", l.tail(), imageID)
+}
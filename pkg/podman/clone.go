@@ -0,0 +1,124 @@
+package podman
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// clonedContainer holds the settings of a container (as printed by podman inspect) that are copied when cloning it.
+type clonedContainer struct {
+	Name      string
+	ImageName string
+	Config    struct {
+		Env        []string
+		Cmd        []string
+		Entrypoint inspectEntrypoint
+		WorkingDir string
+		User       string
+		Labels     map[string]string
+	}
+	HostConfig struct {
+		Binds        []string
+		NetworkMode  string
+		PortBindings map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string
+		}
+		Memory        int64
+		NanoCpus      int64
+		RestartPolicy *struct {
+			Name              string
+			MaximumRetryCount uint
+		}
+	}
+}
+
+// inspectEntrypoint is the entrypoint of an inspected container, printed as a space separated string by podman v4.
+type inspectEntrypoint []string
+
+func (e *inspectEntrypoint) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*e = strings.Fields(s)
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(e))
+}
+
+// cloneRunOptions returns the options to create a copy of the container from its inspect JSON object, with the clone options applied.
+// The environment of the cloned container includes the image environment, except for the HOSTNAME variable set by podman.
+func cloneRunOptions(inspect []byte, opts ContainerCloneOptions) (ContainerRunOptions, error) {
+	var c clonedContainer
+	if err := json.Unmarshal(inspect, &c); err != nil {
+		return ContainerRunOptions{}, fmt.Errorf("failed to parse container inspect output: %w", err)
+	}
+	runOpts := ContainerRunOptions{
+		Image:      c.ImageName,
+		Name:       strings.TrimPrefix(c.Name, "/") + "-clone",
+		Volumes:    c.HostConfig.Binds,
+		Command:    c.Config.Cmd,
+		Entrypoint: []string(c.Config.Entrypoint),
+		WorkDir:    c.Config.WorkingDir,
+		User:       c.Config.User,
+		CPUs:       float64(c.HostConfig.NanoCpus) / 1e9,
+	}
+	if c.HostConfig.NetworkMode != "" && c.HostConfig.NetworkMode != "default" {
+		runOpts.Network = c.HostConfig.NetworkMode
+	}
+	if c.HostConfig.Memory > 0 {
+		runOpts.Memory = strconv.FormatInt(c.HostConfig.Memory, 10)
+	}
+	if rp := c.HostConfig.RestartPolicy; rp != nil && rp.Name != "" && rp.Name != "no" {
+		runOpts.RestartPolicy = rp.Name
+		if rp.MaximumRetryCount > 0 {
+			runOpts.RestartPolicy += ":" + strconv.FormatUint(uint64(rp.MaximumRetryCount), 10)
+		}
+	}
+	for _, port := range slices.Sorted(maps.Keys(c.HostConfig.PortBindings)) {
+		for _, b := range c.HostConfig.PortBindings[port] {
+			binding, err := newPortBinding(port, b.HostIP, b.HostPort)
+			if err != nil {
+				return ContainerRunOptions{}, fmt.Errorf("failed to clone the port bindings of the container: %w", err)
+			}
+			runOpts.PortMappings = append(runOpts.PortMappings, PortMapping{
+				HostIP:        b.HostIP,
+				HostPort:      binding.HostPort,
+				ContainerPort: binding.ContainerPort,
+				Protocol:      binding.Protocol,
+			})
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(c.Config.Labels)) {
+		runOpts.Labels = append(runOpts.Labels, key+"="+c.Config.Labels[key])
+	}
+	env := make(map[string]int)
+	for _, e := range slices.Concat(c.Config.Env, opts.Env) {
+		key, _, _ := strings.Cut(e, "=")
+		if key == "HOSTNAME" {
+			continue
+		}
+		if i, ok := env[key]; ok {
+			runOpts.Env[i] = e
+			continue
+		}
+		env[key] = len(runOpts.Env)
+		runOpts.Env = append(runOpts.Env, e)
+	}
+	if opts.Name != "" {
+		runOpts.Name = opts.Name
+	}
+	if opts.Image != "" {
+		runOpts.Image = opts.Image
+	}
+	if opts.Memory != "" {
+		runOpts.Memory = opts.Memory
+	}
+	if opts.CPUs > 0 {
+		runOpts.CPUs = opts.CPUs
+	}
+	return runOpts, nil
+}
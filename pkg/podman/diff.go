@@ -0,0 +1,58 @@
+package podman
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// fsChange is a filesystem change of a container compared to its image.
+type fsChange struct {
+	// Kind is the podman diff change indicator: A (added), C (changed) or D (deleted).
+	Kind byte
+	Path string
+}
+
+// containerChanges holds the filesystem changes grouped by kind, as printed by podman diff --format json.
+type containerChanges struct {
+	Changed []string `json:"changed,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Deleted []string `json:"deleted,omitempty"`
+}
+
+// formatDiff formats the changes under pathPrefix (all if empty) sorted by path,
+// as the podman diff "<kind> <path>" lines, or grouped by kind in JSON.
+func formatDiff(changes []fsChange, pathPrefix string, outputFormat string) (string, error) {
+	changes = slices.DeleteFunc(changes, func(c fsChange) bool {
+		return !hasPathPrefix(c.Path, pathPrefix)
+	})
+	slices.SortStableFunc(changes, func(a, b fsChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	if outputFormat == config.OutputFormatJSON {
+		var grouped containerChanges
+		for _, c := range changes {
+			switch c.Kind {
+			case 'A':
+				grouped.Added = append(grouped.Added, c.Path)
+			case 'C':
+				grouped.Changed = append(grouped.Changed, c.Path)
+			case 'D':
+				grouped.Deleted = append(grouped.Deleted, c.Path)
+			}
+		}
+		return toJSON(grouped)
+	}
+	lines := make([]string, 0, len(changes))
+	for _, c := range changes {
+		lines = append(lines, string(c.Kind)+" "+c.Path)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// hasPathPrefix reports whether path is prefix or is located under the prefix directory.
+func hasPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/")
+}
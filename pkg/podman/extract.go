@@ -0,0 +1,88 @@
+package podman
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// extractArchive extracts the tar archive into the host directory, renaming the archive root to rename (if not empty).
+// The extraction is confined to dir: entries (or symlinks) pointing outside of it fail to extract.
+func extractArchive(r io.Reader, dir string, rename string) error {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = root.Close() }()
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := archiveEntryName(header.Name, rename)
+		if name == "" {
+			continue
+		}
+		if parent := filepath.Dir(name); parent != "." {
+			if err = root.MkdirAll(parent, 0o755); err != nil {
+				return err
+			}
+		}
+		mode := header.FileInfo().Mode().Perm()
+		if header.Typeflag != tar.TypeDir {
+			// Replace existing files and symlinks instead of writing through them
+			if info, err := root.Lstat(name); err == nil && !info.IsDir() {
+				if err = root.Remove(name); err != nil {
+					return err
+				}
+			}
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = root.MkdirAll(name, mode)
+		case tar.TypeReg:
+			err = extractFile(root, name, mode, tr)
+		case tar.TypeSymlink:
+			err = root.Symlink(header.Linkname, name)
+		case tar.TypeLink:
+			err = root.Link(archiveEntryName(header.Linkname, rename), name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// extractFile writes the content of the current archive entry to the file in root.
+func extractFile(root *os.Root, name string, mode os.FileMode, r io.Reader) error {
+	f, err := root.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// archiveEntryName returns the cleaned, relative, host path of an archive entry with its root element renamed to rename (if not empty).
+func archiveEntryName(name string, rename string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" {
+		return ""
+	}
+	if rename != "" {
+		_, rest, _ := strings.Cut(name, "/")
+		name = path.Join(rename, rest)
+	}
+	return filepath.FromSlash(name)
+}
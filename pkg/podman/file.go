@@ -0,0 +1,118 @@
+package podman
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// containerFile is a file or directory listed in a container.
+type containerFile struct {
+	Name       string    `json:"name"`
+	Size       int64     `json:"size"`
+	Mode       string    `json:"mode"`
+	ModTime    time.Time `json:"modTime"`
+	IsDir      bool      `json:"isDir"`
+	LinkTarget string    `json:"linkTarget,omitempty"`
+}
+
+// listArchive returns the entries of the directory archived in the tar stream (as returned by the archive endpoint or podman cp),
+// or the archived file itself if the archive doesn't contain a directory.
+// Only the direct children of the archive root are listed, the content of the subdirectories is skipped.
+func listArchive(r io.Reader) ([]containerFile, error) {
+	var rootFile *containerFile
+	var files []containerFile
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.Trim(path.Clean("/"+header.Name), "/")
+		_, rest, _ := strings.Cut(name, "/")
+		if strings.Contains(rest, "/") {
+			continue
+		}
+		info := header.FileInfo()
+		file := containerFile{
+			Name:    info.Name(),
+			Size:    info.Size(),
+			Mode:    info.Mode().String(),
+			ModTime: info.ModTime().UTC(),
+			IsDir:   info.IsDir(),
+		}
+		if header.Typeflag == tar.TypeSymlink {
+			file.LinkTarget = header.Linkname
+		}
+		if rest == "" {
+			rootFile = &file
+			continue
+		}
+		files = append(files, file)
+	}
+	if rootFile != nil && !rootFile.IsDir {
+		return []containerFile{*rootFile}, nil
+	}
+	return files, nil
+}
+
+// formatFileList formats the container files as a table similar to ls -l, or as JSON.
+func formatFileList(files []containerFile, outputFormat string) (string, error) {
+	if outputFormat == config.OutputFormatJSON {
+		if files == nil {
+			files = []containerFile{}
+		}
+		return toJSON(files)
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "MODE\tSIZE\tMODIFIED\tNAME")
+	for _, f := range files {
+		name := f.Name
+		if f.LinkTarget != "" {
+			name += " -> " + f.LinkTarget
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", f.Mode, f.Size, f.ModTime.Format(time.RFC3339), name)
+	}
+	_ = w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// checkFileSize returns an error if the container file can't be read because it's a directory or exceeds maxSize bytes.
+func checkFileSize(filePath string, isDir bool, size int64, maxSize int64) error {
+	if isDir {
+		return fmt.Errorf("%s is a directory", filePath)
+	}
+	if size > maxSize {
+		return fmt.Errorf("%s size (%d bytes) exceeds the maximum readable size of %d bytes", filePath, size, maxSize)
+	}
+	return nil
+}
+
+// readArchiveFile reads the content of the file archived in the tar stream (as returned by the archive endpoint or podman cp).
+// The tar header is checked before reading the content so that directories and files exceeding maxSize aren't read.
+func readArchiveFile(r io.Reader, filePath string, maxSize int64) ([]byte, error) {
+	tr := tar.NewReader(r)
+	header, err := tr.Next()
+	if err != nil {
+		return nil, err
+	}
+	if header.Typeflag == tar.TypeSymlink {
+		return nil, fmt.Errorf("%s is a symbolic link to %s", filePath, header.Linkname)
+	}
+	if err = checkFileSize(filePath, header.Typeflag == tar.TypeDir, header.Size, maxSize); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(io.LimitReader(tr, maxSize))
+}
@@ -0,0 +1,32 @@
+package podman
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// formatHealth formats the healthcheck state of a container keeping only the last logs probe results (all if logs <= 0),
+// as a human-readable report, or as JSON.
+func formatHealth(name string, health *ContainerHealth, logs int, outputFormat string) (string, error) {
+	if health == nil {
+		return "", fmt.Errorf("container %s has no healthcheck defined", name)
+	}
+	result := *health
+	if logs > 0 && len(result.Log) > logs {
+		result.Log = result.Log[len(result.Log)-logs:]
+	}
+	if result.Log == nil {
+		result.Log = []HealthcheckLog{}
+	}
+	if outputFormat == config.OutputFormatJSON {
+		return toJSON(result)
+	}
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "Status: %s\nFailing streak: %d\n", result.Status, result.FailingStreak)
+	for _, l := range result.Log {
+		_, _ = fmt.Fprintf(&b, "\nProbe started %s, exit code %d:\n%s\n", l.Start, l.ExitCode, strings.TrimRight(l.Output, "\n"))
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
@@ -0,0 +1,78 @@
+package podman
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// imageHistoryLayer is a layer of an image history, from the most recent to the base layer.
+type imageHistoryLayer struct {
+	ID        string    `json:"id"`
+	Created   time.Time `json:"created"`
+	CreatedBy string    `json:"createdBy"`
+	Tags      []string  `json:"tags,omitempty"`
+	Size      int64     `json:"size"`
+	Comment   string    `json:"comment,omitempty"`
+}
+
+// parseImageHistory parses the JSON output of podman history --format json.
+func parseImageHistory(output string) ([]imageHistoryLayer, error) {
+	var layers []struct {
+		ID        string    `json:"id"`
+		Created   time.Time `json:"created"`
+		CreatedBy string    `json:"CreatedBy"`
+		Tags      []string  `json:"tags"`
+		Size      int64     `json:"size"`
+		Comment   string    `json:"comment"`
+	}
+	if err := json.Unmarshal([]byte(output), &layers); err != nil {
+		return nil, fmt.Errorf("failed to parse image history: %w", err)
+	}
+	history := make([]imageHistoryLayer, 0, len(layers))
+	for _, l := range layers {
+		history = append(history, imageHistoryLayer(l))
+	}
+	return history, nil
+}
+
+// layerInstruction returns the Containerfile instruction that created a layer from its shell command,
+// as recorded by the image builders: RUN commands are run by a shell, other instructions are marked as #(nop).
+func layerInstruction(createdBy string) string {
+	instruction := strings.TrimSpace(createdBy)
+	if nop, ok := strings.CutPrefix(instruction, "/bin/sh -c #(nop)"); ok {
+		return strings.TrimSpace(nop)
+	}
+	if run, ok := strings.CutPrefix(instruction, "/bin/sh -c "); ok {
+		return "RUN " + strings.TrimSpace(run)
+	}
+	return instruction
+}
+
+// formatImageHistory formats the image layers with their size and creating instruction as a table, or as JSON.
+func formatImageHistory(layers []imageHistoryLayer, outputFormat string) (string, error) {
+	for i := range layers {
+		layers[i].ID = strings.TrimPrefix(layers[i].ID, "sha256:")
+		layers[i].Created = layers[i].Created.UTC()
+		layers[i].CreatedBy = layerInstruction(layers[i].CreatedBy)
+	}
+	if outputFormat == config.OutputFormatJSON {
+		if layers == nil {
+			layers = []imageHistoryLayer{}
+		}
+		return toJSON(layers)
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tCREATED\tSIZE\tCREATED BY")
+	for _, l := range layers {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", shortID(l.ID), l.Created.Format(time.RFC3339), formatSize(l.Size), l.CreatedBy)
+	}
+	_ = w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
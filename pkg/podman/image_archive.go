@@ -0,0 +1,62 @@
+package podman
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Formats of the image archives created by ImageSave.
+const (
+	imageFormatDockerArchive = "docker-archive"
+	imageFormatOCIArchive    = "oci-archive"
+	imageFormatOCIDir        = "oci-dir"
+)
+
+// imageSaveFormat returns the format to save the images in, docker-archive if not specified.
+// Only the docker-archive format supports saving multiple images into the same archive.
+func imageSaveFormat(images []string, format string) (string, error) {
+	if len(images) == 0 {
+		return "", errors.New("at least one image is required")
+	}
+	format = cmp.Or(format, imageFormatDockerArchive)
+	switch format {
+	case imageFormatDockerArchive:
+		return format, nil
+	case imageFormatOCIArchive, imageFormatOCIDir:
+		if len(images) > 1 {
+			return "", fmt.Errorf("the %s format doesn't support multiple images, use %s instead", format, imageFormatDockerArchive)
+		}
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported image archive format %s, expected one of %s, %s or %s",
+		format, imageFormatDockerArchive, imageFormatOCIArchive, imageFormatOCIDir)
+}
+
+// createImageDir creates the host directory an image is saved to in the oci-dir format, it may already exist.
+func createImageDir(path string) error {
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && !info.IsDir():
+		return fmt.Errorf("%s already exists and is not a directory", path)
+	case err == nil:
+		return nil
+	case errors.Is(err, os.ErrNotExist):
+		return os.Mkdir(path, 0o755)
+	}
+	return err
+}
+
+// formatLoadedImages lists the names of the images loaded from an archive as podman load does.
+func formatLoadedImages(names []string) string {
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("Loaded image: " + name)
+	}
+	return b.String()
+}
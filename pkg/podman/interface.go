@@ -2,38 +2,115 @@ package podman
 
 import (
 	"cmp"
+	"context"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/manusa/podman-mcp-server/pkg/config"
 )
 
 // Podman interface
 type Podman interface {
+	// ContainerClone creates a copy of a container with the settings in opts changed and returns the new container ID
+	ContainerClone(name string, opts ContainerCloneOptions) (string, error)
+	// ContainerCommit creates an image named imageName from the changes of a container, applying the Containerfile instructions in changes
+	ContainerCommit(name string, imageName string, message string, author string, changes []string) (string, error)
+	// ContainerCopyFrom copies a file or directory from a container to the host path
+	ContainerCopyFrom(name string, containerPath string, hostPath string) error
+	// ContainerCopyTo copies a file or directory from the host path to a container
+	ContainerCopyTo(name string, hostPath string, containerPath string) error
+	// ContainerDiff Display the filesystem changes of a container compared to its image, under pathPrefix if provided
+	ContainerDiff(name string, pathPrefix string) (string, error)
+	// ContainerExec executes a command in a running container
+	ContainerExec(name string, command []string, env []string, workdir string, user string) (*ExecResult, error)
+	// ContainerFileList lists the directory (or file) of a container with the sizes and modes of its entries
+	ContainerFileList(name string, filePath string) (string, error)
+	// ContainerFileRead reads the content of a file of a container, failing if the file exceeds maxSize bytes
+	ContainerFileRead(name string, filePath string, maxSize int64) ([]byte, error)
+	// ContainerFileWrite writes the content to a file of a container, replacing it if it exists
+	ContainerFileWrite(name string, filePath string, content []byte) error
+	// ContainerHealth reports the healthcheck status, failing streak and last logs probe results (all if logs <= 0) of a container
+	ContainerHealth(name string, logs int) (string, error)
+	// ContainerHealthcheckRun runs the healthcheck of a container and returns the resulting status
+	ContainerHealthcheckRun(name string) (string, error)
 	// ContainerInspect displays the low-level information on containers identified by the ID or name
 	ContainerInspect(name string) (string, error)
-	// ContainerList lists all the containers on the system
-	ContainerList() (string, error)
-	// ContainerLogs Display the logs of a container
-	ContainerLogs(name string) (string, error)
+	// ContainerKill sends a signal to a running container using the ID or name
+	ContainerKill(name string, signal string) (string, error)
+	// ContainerList lists the containers on the system matching the options
+	ContainerList(opts ContainerListOptions) (string, error)
+	// ContainerLogs Display the logs of a container filtered by the provided options
+	ContainerLogs(name string, opts LogOptions) (string, error)
+	// ContainerLogsFollow Stream the logs of a container to onLine until ctx is done or onLine returns false
+	ContainerLogsFollow(ctx context.Context, name string, opts LogOptions, onLine func(line string) bool) error
+	// ContainerPause pauses all the processes in a running container using the ID or name
+	ContainerPause(name string) (string, error)
+	// ContainerPort lists the host port bindings of a container
+	ContainerPort(name string) (string, error)
+	// ContainerPrune removes the stopped containers matching the filters, or only reports them (and their size) if dryRun is set
+	ContainerPrune(filters map[string][]string, dryRun bool) (string, error)
 	// ContainerRemove removes a container
 	ContainerRemove(name string) (string, error)
-	// ContainerRun pulls an image from a registry
-	ContainerRun(imageName string, portMappings map[int]int, envVariables []string) (string, error)
+	// ContainerRename changes the name of a container
+	ContainerRename(name string, newName string) (string, error)
+	// ContainerRestart restarts a container using the ID or name
+	ContainerRestart(name string) (string, error)
+	// ContainerRun creates and starts a new container from an image, pulling the image if needed
+	ContainerRun(opts ContainerRunOptions) (string, error)
+	// ContainerRunCommand runs a container until its command exits and returns its output and exit code, removing it afterward.
+	// The container is killed and removed if it doesn't exit before the timeout expires (no timeout if <= 0)
+	ContainerRunCommand(ctx context.Context, opts ContainerRunOptions, timeout time.Duration) (*ExecResult, error)
+	// ContainerStart starts a stopped container using the ID or name
+	ContainerStart(name string) (string, error)
+	// ContainerStats Display the resource usage statistics of the specified containers (all running containers if none specified), sampling them over the options window until ctx is done
+	ContainerStats(ctx context.Context, names []string, opts StatsOptions) (string, error)
 	// ContainerStop stops a running container using the ID or name
 	ContainerStop(name string) (string, error)
-	// ImageBuild builds an image from a Dockerfile, Podmanfile, or Containerfile
-	ImageBuild(containerFile string, imageName string) (string, error)
+	// ContainerTop Display the running processes of a container, psArgs are podman top format descriptors or ps(1) options
+	ContainerTop(name string, psArgs []string) (string, error)
+	// ContainerUnpause resumes all the processes in a paused container using the ID or name
+	ContainerUnpause(name string) (string, error)
+	// ContainerUpdate changes the resource limits and restart policy of a container without recreating it
+	ContainerUpdate(name string, opts ContainerUpdateOptions) (string, error)
+	// ContainerWait blocks until the container meets one of the conditions (stopped or exited if none) or the timeout expires (no timeout if <= 0)
+	ContainerWait(ctx context.Context, name string, conditions []string, timeout time.Duration) (*WaitResult, error)
+	// ImageBuild builds an image from a Dockerfile, Podmanfile, or Containerfile with the provided options,
+	// writing the inline Containerfile and build context to a temporary directory removed after the build.
+	// Each build output line is passed to onLine as it's produced (optional), the result is the trimmed build output
+	// followed by the image ID. The build is stopped when ctx is done.
+	ImageBuild(ctx context.Context, opts ImageBuildOptions, onLine func(line string)) (string, error)
+	// ImageHistory lists the layers of an image with their size and creating instruction
+	ImageHistory(imageName string) (string, error)
+	// ImageInspect displays the low-level information and configuration of an image identified by the ID or name
+	ImageInspect(imageName string) (string, error)
 	// ImageList list the container images on the system
 	ImageList() (string, error)
+	// ImageLoad loads the images from the docker-archive or oci-archive tarball, or oci-dir directory, at the host path
+	ImageLoad(path string) (string, error)
+	// ImagePrune removes the dangling images (all the unused images if all is set) matching the filters, or only reports them (and their size) if dryRun is set
+	ImagePrune(all bool, filters map[string][]string, dryRun bool) (string, error)
 	// ImagePull pulls an image from a registry
 	ImagePull(imageName string) (string, error)
 	// ImagePush pushes an image to a registry
 	ImagePush(imageName string) (string, error)
 	// ImageRemove removes an image from the system
 	ImageRemove(imageName string) (string, error)
+	// ImageSave saves the images to the host path in the docker-archive (default, the only format for multiple images),
+	// oci-archive or oci-dir format
+	ImageSave(images []string, path string, format string) error
+	// ImageTag adds the target name (and tag) to the source image
+	ImageTag(source string, target string) (string, error)
+	// ImageUntag removes the name (and tag) from an image, or all its names if tag is empty
+	ImageUntag(imageName string, tag string) (string, error)
 	// NetworkList lists all the networks on the system
 	NetworkList() (string, error)
+	// RegistryListLogins lists the registries with credentials stored in the auth file and their usernames, never the secrets
+	RegistryListLogins() (string, error)
+	// RegistryLogin verifies the credentials against the registry and stores them in the auth file
+	RegistryLogin(registry string, opts RegistryLoginOptions) (string, error)
+	// RegistryLogout removes the credentials of the registry from the auth file, or of all the registries if all is set
+	RegistryLogout(registry string, all bool) (string, error)
 	// VolumeList lists all the volumes on the system
 	VolumeList() (string, error)
 }
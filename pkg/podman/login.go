@@ -0,0 +1,57 @@
+package podman
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	dockerConfig "go.podman.io/image/v5/pkg/docker/config"
+	imageTypes "go.podman.io/image/v5/types"
+
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// registryLogin is a registry with stored credentials, the secrets are never included.
+type registryLogin struct {
+	Registry string `json:"registry"`
+	Username string `json:"username"`
+}
+
+// listRegistryLogins returns the registries with credentials stored in the auth file (podman default if empty)
+// or in the configured credential helpers, sorted by registry.
+func listRegistryLogins(authFile string) ([]registryLogin, error) {
+	credentials, err := dockerConfig.GetAllCredentials(&imageTypes.SystemContext{AuthFilePath: authFile})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the registry credentials: %w", err)
+	}
+	logins := make([]registryLogin, 0, len(credentials))
+	for _, registry := range slices.Sorted(maps.Keys(credentials)) {
+		username := credentials[registry].Username
+		if username == "" && credentials[registry].IdentityToken != "" {
+			username = "<identity token>"
+		}
+		logins = append(logins, registryLogin{Registry: registry, Username: username})
+	}
+	return logins, nil
+}
+
+// formatRegistryLogins formats the registry logins as a table, or as JSON.
+func formatRegistryLogins(logins []registryLogin, outputFormat string) (string, error) {
+	if outputFormat == config.OutputFormatJSON {
+		return toJSON(logins)
+	}
+	if len(logins) == 0 {
+		return "No registry logins found", nil
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "REGISTRY\tUSERNAME")
+	for _, l := range logins {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", l.Registry, l.Username)
+	}
+	_ = w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
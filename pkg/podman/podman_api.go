@@ -3,25 +3,40 @@
 package podman
 
 import (
+	"archive/tar"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"text/tabwriter"
 	"time"
 
 	buildahDefine "github.com/containers/buildah/define"
+	"github.com/containers/podman/v5/pkg/api/handlers"
 	"github.com/containers/podman/v5/pkg/bindings"
 	"github.com/containers/podman/v5/pkg/bindings/containers"
 	"github.com/containers/podman/v5/pkg/bindings/images"
 	"github.com/containers/podman/v5/pkg/bindings/network"
 	"github.com/containers/podman/v5/pkg/bindings/volumes"
+	podmanCopy "github.com/containers/podman/v5/pkg/copy"
+	entitiesReports "github.com/containers/podman/v5/pkg/domain/entities/reports"
 	entitiesTypes "github.com/containers/podman/v5/pkg/domain/entities/types"
 	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/distribution/reference"
+	"github.com/docker/go-units"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	netTypes "go.podman.io/common/libnetwork/types"
+	"go.podman.io/common/pkg/auth"
+	imageTypes "go.podman.io/image/v5/types"
 
 	"github.com/manusa/podman-mcp-server/pkg/config"
 )
@@ -35,6 +50,7 @@ func init() {
 type podmanApi struct {
 	ctx          context.Context // Context with connection info
 	outputFormat string
+	authFile     string
 	initOnce     sync.Once
 	initErr      error
 }
@@ -69,6 +85,7 @@ func (p *podmanApi) Priority() int {
 func (p *podmanApi) Initialize(cfg config.Config) (Podman, error) {
 	instance := &podmanApi{
 		outputFormat: cfg.OutputFormat,
+		authFile:     cfg.AuthFile,
 	}
 	if err := instance.ensureConnection(); err != nil {
 		return nil, err
@@ -93,6 +110,266 @@ func (p *podmanApi) ensureConnection() error {
 	return p.initErr
 }
 
+// ContainerClone creates a copy of a container from its inspect data.
+func (p *podmanApi) ContainerClone(name string, opts ContainerCloneOptions) (string, error) {
+	data, err := containers.Inspect(p.ctx, name, nil)
+	if err != nil {
+		return "", err
+	}
+	inspect, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	runOpts, err := cloneRunOptions(inspect, opts)
+	if err != nil {
+		return "", err
+	}
+	// Best-effort pull of a replacement image, see ContainerRun
+	if opts.Image != "" {
+		_, _, _ = p.pullImageWithShortNameRetry(runOpts.Image)
+	}
+	s, err := newSpecGenerator(runOpts)
+	if err != nil {
+		return "", err
+	}
+	// Only the ports published by the cloned container are published
+	if len(runOpts.PortMappings) == 0 {
+		s.PublishExposedPorts = nil
+	}
+	id, err := p.createWithShortNameRetry(s, runOpts.Image)
+	if err != nil {
+		return "", err
+	}
+	if opts.Start {
+		if err = containers.Start(p.ctx, id, nil); err != nil {
+			return "", err
+		}
+	}
+	return id, nil
+}
+
+// ContainerCommit creates a new image from the changes of a container.
+func (p *podmanApi) ContainerCommit(name string, imageName string, message string, author string, changes []string) (string, error) {
+	opts := &containers.CommitOptions{Changes: changes}
+	if imageName != "" {
+		ref, err := reference.Parse(imageName)
+		if err != nil {
+			return "", fmt.Errorf("invalid image name %s: %w", imageName, err)
+		}
+		if named, ok := ref.(reference.Named); ok {
+			repo := named.Name()
+			opts.Repo = &repo
+		}
+		if tagged, ok := ref.(reference.Tagged); ok {
+			tag := tagged.Tag()
+			opts.Tag = &tag
+		}
+	}
+	if message != "" {
+		// The commit message is only supported by the docker image format
+		format := "docker"
+		opts.Format = &format
+		opts.Comment = &message
+	}
+	if author != "" {
+		opts.Author = &author
+	}
+	response, err := containers.Commit(p.ctx, name, opts)
+	if err != nil {
+		return "", err
+	}
+	return response.ID, nil
+}
+
+// ContainerCopyFrom copies a file or directory from a container to the host path.
+func (p *podmanApi) ContainerCopyFrom(name string, containerPath string, hostPath string) error {
+	dir, rename := hostCopyDestination(hostPath)
+	return p.readArchive(name, containerPath, func(r io.Reader) error {
+		return extractArchive(r, dir, rename)
+	})
+}
+
+// ContainerCopyTo copies a file or directory from the host path to a container.
+func (p *podmanApi) ContainerCopyTo(name string, hostPath string, containerPath string) error {
+	// As in podman cp, copy into the destination if it's an existing directory, or as the destination otherwise
+	dir, rootName := containerPath, filepath.Base(hostPath)
+	stat, err := containers.Stat(p.ctx, name, containerPath)
+	if err != nil && !errors.Is(err, podmanCopy.ErrENOENT) {
+		return err
+	}
+	if err != nil || !stat.IsDir {
+		dir, rootName = path.Dir(containerPath), path.Base(containerPath)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(createArchive(pw, hostPath, rootName))
+	}()
+	defer func() { _ = pr.Close() }()
+	copyFunc, err := containers.CopyFromArchive(p.ctx, name, dir, pr)
+	if err != nil {
+		return err
+	}
+	return copyFunc()
+}
+
+// readArchive streams the tar archive of the container path to read.
+func (p *podmanApi) readArchive(name string, containerPath string, read func(r io.Reader) error) error {
+	pr, pw := io.Pipe()
+	copyFunc, err := containers.CopyToArchive(p.ctx, name, containerPath, pw)
+	if err != nil {
+		return err
+	}
+	go func() {
+		_ = pw.CloseWithError(copyFunc())
+	}()
+	err = read(pr)
+	// Unblock the archive download if the read finished early
+	_ = pr.CloseWithError(err)
+	return err
+}
+
+// ContainerDiff returns the filesystem changes of a container compared to its image.
+func (p *podmanApi) ContainerDiff(name string, pathPrefix string) (string, error) {
+	diffType := "container"
+	changes, err := containers.Diff(p.ctx, name, &containers.DiffOptions{DiffType: &diffType})
+	if err != nil {
+		return "", err
+	}
+	fsChanges := make([]fsChange, 0, len(changes))
+	for _, c := range changes {
+		if kind := c.Kind.String(); kind != "" {
+			fsChanges = append(fsChanges, fsChange{Kind: kind[0], Path: c.Path})
+		}
+	}
+	return formatDiff(fsChanges, pathPrefix, p.outputFormat)
+}
+
+// ContainerExec executes a command in a running container.
+func (p *podmanApi) ContainerExec(name string, command []string, env []string, workdir string, user string) (*ExecResult, error) {
+	execConfig := new(handlers.ExecCreateConfig)
+	execConfig.Cmd = command
+	execConfig.Env = env
+	execConfig.WorkingDir = workdir
+	execConfig.User = user
+	execConfig.AttachStdout = true
+	execConfig.AttachStderr = true
+	sessionID, err := containers.ExecCreate(p.ctx, name, execConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdoutBuf, stderrBuf bytes.Buffer
+	var stdout, stderr io.Writer = &stdoutBuf, &stderrBuf
+	opts := &containers.ExecStartAndAttachOptions{
+		OutputStream: &stdout,
+		ErrorStream:  &stderr,
+		AttachOutput: boolPtr(true),
+		AttachError:  boolPtr(true),
+	}
+	if err := containers.ExecStartAndAttach(p.ctx, sessionID, opts); err != nil {
+		return nil, err
+	}
+
+	session, err := containers.ExecInspect(p.ctx, sessionID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ExecResult{
+		Stdout:   stdoutBuf.String(),
+		Stderr:   stderrBuf.String(),
+		ExitCode: session.ExitCode,
+	}, nil
+}
+
+// ContainerFileList lists the directory (or file) of a container.
+func (p *podmanApi) ContainerFileList(name string, filePath string) (string, error) {
+	var files []containerFile
+	err := p.readArchive(name, filePath, func(r io.Reader) (err error) {
+		files, err = listArchive(r)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return formatFileList(files, p.outputFormat)
+}
+
+// ContainerFileRead reads the content of a file of a container.
+func (p *podmanApi) ContainerFileRead(name string, filePath string, maxSize int64) ([]byte, error) {
+	// Check the (symlink resolved) file before downloading it
+	stat, err := containers.Stat(p.ctx, name, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkFileSize(filePath, stat.IsDir, stat.Size, maxSize); err != nil {
+		return nil, err
+	}
+	if stat.LinkTarget != "" {
+		filePath = stat.LinkTarget
+	}
+	var content []byte
+	err = p.readArchive(name, filePath, func(r io.Reader) error {
+		content, err = readArchiveFile(r, filePath, maxSize)
+		return err
+	})
+	return content, err
+}
+
+// ContainerFileWrite writes the content to a file of a container.
+func (p *podmanApi) ContainerFileWrite(name string, filePath string, content []byte) error {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Base(filePath),
+		Mode:     0o644,
+		Size:     int64(len(content)),
+		ModTime:  time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if _, err := tw.Write(content); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	copyFunc, err := containers.CopyFromArchive(p.ctx, name, path.Dir(filePath), &buf)
+	if err != nil {
+		return err
+	}
+	return copyFunc()
+}
+
+// ContainerHealth returns the healthcheck state of a container from its inspect data.
+func (p *podmanApi) ContainerHealth(name string, logs int) (string, error) {
+	data, err := containers.Inspect(p.ctx, name, nil)
+	if err != nil {
+		return "", err
+	}
+	var health *ContainerHealth
+	if data.State != nil && data.State.Health != nil {
+		health = &ContainerHealth{
+			Status:        data.State.Health.Status,
+			FailingStreak: data.State.Health.FailingStreak,
+		}
+		for _, l := range data.State.Health.Log {
+			health.Log = append(health.Log, HealthcheckLog{Start: l.Start, End: l.End, ExitCode: l.ExitCode, Output: l.Output})
+		}
+	}
+	return formatHealth(name, health, logs, p.outputFormat)
+}
+
+// ContainerHealthcheckRun runs the healthcheck of a container.
+func (p *podmanApi) ContainerHealthcheckRun(name string) (string, error) {
+	result, err := containers.RunHealthCheck(p.ctx, name, nil)
+	if err != nil {
+		return "", err
+	}
+	return result.Status, nil
+}
+
 // ContainerInspect displays the low-level information on containers identified by ID or name.
 func (p *podmanApi) ContainerInspect(name string) (string, error) {
 	data, err := containers.Inspect(p.ctx, name, nil)
@@ -102,13 +379,25 @@ func (p *podmanApi) ContainerInspect(name string) (string, error) {
 	return toJSON(data)
 }
 
-// ContainerList lists all containers on the system.
-func (p *podmanApi) ContainerList() (string, error) {
-	all := true
-	opts := &containers.ListOptions{
-		All: &all,
+// ContainerKill sends a signal to a running container.
+func (p *podmanApi) ContainerKill(name string, signal string) (string, error) {
+	opts := &containers.KillOptions{}
+	if signal != "" {
+		opts.Signal = &signal
 	}
-	data, err := containers.List(p.ctx, opts)
+	if err := containers.Kill(p.ctx, name, opts); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// ContainerList lists the containers on the system matching the options.
+func (p *podmanApi) ContainerList(opts ContainerListOptions) (string, error) {
+	listOpts := &containers.ListOptions{
+		All:     &opts.All,
+		Filters: opts.filters(),
+	}
+	data, err := containers.List(p.ctx, listOpts)
 	if err != nil {
 		return "", err
 	}
@@ -118,74 +407,91 @@ func (p *podmanApi) ContainerList() (string, error) {
 	return formatContainerList(data), nil
 }
 
-// ContainerLogs returns the logs of a container.
-func (p *podmanApi) ContainerLogs(name string) (string, error) {
-	stdout := true
-	stderr := true
-	opts := &containers.LogOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
+// ContainerLogs returns the logs of a container filtered by the provided options.
+func (p *podmanApi) ContainerLogs(name string, opts LogOptions) (string, error) {
+	var logs bytes.Buffer
+	err := streamLogs(p.ctx, name, toBindingsLogOptions(opts), func(line string) bool {
+		logs.WriteString(line)
+		return true
+	})
+	if err != nil {
+		return "", err
 	}
+	return logs.String(), nil
+}
 
-	stdoutChan := make(chan string)
-	stderrChan := make(chan string)
-
-	// Create a context with timeout to prevent hanging
-	ctx, cancel := context.WithTimeout(p.ctx, 30*time.Second)
+// ContainerLogsFollow streams the logs of a container to onLine until ctx is done or onLine returns false.
+func (p *podmanApi) ContainerLogsFollow(ctx context.Context, name string, opts LogOptions, onLine func(line string) bool) error {
+	logOpts := toBindingsLogOptions(opts)
+	logOpts.Follow = boolPtr(true)
+	// The bindings connection is stored in p.ctx, stop following when the caller's context is done too.
+	followCtx, cancel := context.WithCancel(p.ctx)
 	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+	return streamLogs(followCtx, name, logOpts, onLine)
+}
 
-	// Collect logs in goroutine.
-	var stdoutBuf, stderrBuf bytes.Buffer
-	done := make(chan struct{})
-	go func() {
-		defer close(done)
-		for {
-			select {
-			case line, ok := <-stdoutChan:
-				if !ok {
-					stdoutChan = nil
-				} else {
-					stdoutBuf.WriteString(line)
-				}
-			case line, ok := <-stderrChan:
-				if !ok {
-					stderrChan = nil
-				} else {
-					stderrBuf.WriteString(line)
+// ContainerPause pauses all the processes in a running container.
+func (p *podmanApi) ContainerPause(name string) (string, error) {
+	if err := containers.Pause(p.ctx, name, nil); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// ContainerPort lists the host port bindings of a container.
+func (p *podmanApi) ContainerPort(name string) (string, error) {
+	data, err := containers.Inspect(p.ctx, name, nil)
+	if err != nil {
+		return "", err
+	}
+	var bindings []portBinding
+	if data.NetworkSettings != nil {
+		for port, hostPorts := range data.NetworkSettings.Ports {
+			for _, hp := range hostPorts {
+				binding, err := newPortBinding(port, hp.HostIP, hp.HostPort)
+				if err != nil {
+					return "", err
 				}
-			case <-ctx.Done():
-				return
-			}
-			if stdoutChan == nil && stderrChan == nil {
-				return
+				bindings = append(bindings, binding)
 			}
 		}
-	}()
-
-	// containers.Logs should close both channels when done
-	err := containers.Logs(ctx, name, opts, stdoutChan, stderrChan)
-
-	// Wait for collection to finish or context to be cancelled
-	select {
-	case <-done:
-	case <-ctx.Done():
-		return "", fmt.Errorf("timeout waiting for container logs")
 	}
+	return formatPortBindings(bindings, p.outputFormat)
+}
 
+// ContainerPrune removes the stopped containers matching the filters, or only reports them if dryRun is set.
+func (p *podmanApi) ContainerPrune(filters map[string][]string, dryRun bool) (string, error) {
+	all, size := true, true
+	data, err := containers.List(p.ctx, &containers.ListOptions{
+		All:     &all,
+		Size:    &size,
+		Filters: containerPruneFilters(filters),
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get container logs: %w", err)
+		return "", err
 	}
-
-	// Combine stdout and stderr
-	result := stdoutBuf.String()
-	if stderrContent := stderrBuf.String(); stderrContent != "" {
-		if result != "" {
-			result += "\n"
+	candidates := make([]pruneResource, 0, len(data))
+	for _, c := range data {
+		candidate := pruneResource{ID: c.ID, Name: strings.Join(c.Names, ",")}
+		if c.Size != nil {
+			candidate.Size = c.Size.RwSize
 		}
-		result += stderrContent
+		candidates = append(candidates, candidate)
 	}
-
-	return result, nil
+	if dryRun {
+		return formatPruneReport("container", candidates, true, p.outputFormat)
+	}
+	reports, err := containers.Prune(p.ctx, &containers.PruneOptions{Filters: filters})
+	if err != nil {
+		return "", err
+	}
+	pruned, err := apiPrunedResources(reports)
+	if err != nil {
+		return "", err
+	}
+	return formatPruneReport("container", prunedResources(candidates, pruned), false, p.outputFormat)
 }
 
 // ContainerRemove removes a container.
@@ -202,60 +508,132 @@ func (p *podmanApi) ContainerRemove(name string) (string, error) {
 	return name, nil
 }
 
+// ContainerRename changes the name of a container.
+func (p *podmanApi) ContainerRename(name string, newName string) (string, error) {
+	if err := containers.Rename(p.ctx, name, new(containers.RenameOptions).WithName(newName)); err != nil {
+		return "", err
+	}
+	return newName, nil
+}
+
+// ContainerRestart restarts a container.
+func (p *podmanApi) ContainerRestart(name string) (string, error) {
+	if err := containers.Restart(p.ctx, name, nil); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
 // ContainerRun runs a new container from an image.
-func (p *podmanApi) ContainerRun(imageName string, portMappings map[int]int, envVariables []string) (string, error) {
+func (p *podmanApi) ContainerRun(opts ContainerRunOptions) (string, error) {
 	// Best-effort pull: unlike the CLI's `podman run` which auto-pulls, the API's
 	// CreateWithSpec does not. We pre-pull here so the image is available locally.
 	// Errors are intentionally ignored — if the image already exists locally or the
 	// pull fails, CreateWithSpec will surface a proper error.
-	_, _, _ = p.pullImageWithShortNameRetry(imageName)
+	_, _, _ = p.pullImageWithShortNameRetry(opts.Image)
 
-	s := specgen.NewSpecGenerator(imageName, false)
-	s.Remove = boolPtr(true) // --rm
+	s, err := newSpecGenerator(opts)
+	if err != nil {
+		return "", err
+	}
 
-	// Port mappings
-	if len(portMappings) > 0 {
-		for hostPort, containerPort := range portMappings {
-			s.PortMappings = append(s.PortMappings, netTypes.PortMapping{
-				HostPort:      uint16(hostPort),
-				ContainerPort: uint16(containerPort),
-				Protocol:      "tcp",
-			})
-		}
-	} else {
-		s.PublishExposedPorts = boolPtr(true) // --publish-all
+	id, err := p.createWithShortNameRetry(s, opts.Image)
+	if err != nil {
+		return "", err
 	}
 
-	// Environment variables (key=value format → map)
-	if len(envVariables) > 0 {
-		s.Env = make(map[string]string)
-		for _, env := range envVariables {
-			parts := strings.SplitN(env, "=", 2)
-			if len(parts) == 2 {
-				s.Env[parts[0]] = parts[1]
-			}
-		}
+	// Start the container
+	if err := containers.Start(p.ctx, id, nil); err != nil {
+		return "", err
 	}
+	return id, nil
+}
 
-	createResponse, err := containers.CreateWithSpec(p.ctx, s, nil)
+// ContainerRunCommand runs a container until its command exits and returns its output, removing it afterward.
+func (p *podmanApi) ContainerRunCommand(ctx context.Context, opts ContainerRunOptions, timeout time.Duration) (*ExecResult, error) {
+	// Best-effort pull, see ContainerRun
+	_, _, _ = p.pullImageWithShortNameRetry(opts.Image)
+
+	s, err := newSpecGenerator(opts)
 	if err != nil {
-		// Short-name retry: if creation fails due to short-name resolution, try with docker.io/ prefix
-		if strings.Contains(err.Error(), "short-name") {
-			s.Image = "docker.io/" + imageName
-			createResponse, err = containers.CreateWithSpec(p.ctx, s, nil)
-			if err != nil {
-				return "", err
-			}
-		} else {
-			return "", err
-		}
+		return nil, err
+	}
+	// The container is removed once its output is collected, and doesn't serve any port
+	s.Remove = boolPtr(false)
+	if len(opts.Ports) == 0 {
+		s.PublishExposedPorts = nil
 	}
 
-	// Start the container
-	if err := containers.Start(p.ctx, createResponse.ID, nil); err != nil {
+	id, err := p.createWithShortNameRetry(s, opts.Image)
+	if err != nil {
+		return nil, err
+	}
+	id = shortID(id)
+	// Remove the container once its output is collected, or kill it if the timeout expires
+	defer func() {
+		var zero uint
+		_, _ = containers.Remove(p.ctx, id, &containers.RemoveOptions{Force: boolPtr(true), Volumes: boolPtr(true), Timeout: &zero})
+	}()
+	if err = containers.Start(p.ctx, id, nil); err != nil {
+		return nil, err
+	}
+	result, err := p.ContainerWait(ctx, id, nil, timeout)
+	if err != nil {
+		return nil, err
+	}
+	stdout, stderr, err := collectOutput(p.ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &ExecResult{Stdout: stdout, Stderr: stderr, ExitCode: result.ExitCode}, nil
+}
+
+// ContainerStart starts a stopped container.
+func (p *podmanApi) ContainerStart(name string) (string, error) {
+	if err := containers.Start(p.ctx, name, nil); err != nil {
 		return "", err
 	}
-	return createResponse.ID, nil
+	return name, nil
+}
+
+// ContainerStats returns the resource usage statistics of the specified containers.
+func (p *podmanApi) ContainerStats(ctx context.Context, names []string, opts StatsOptions) (string, error) {
+	return collectStats(ctx, names, opts, p.outputFormat, p.containerStatsSnapshot)
+}
+
+// containerStatsSnapshot takes a single resource usage statistics sample of the specified containers.
+func (p *podmanApi) containerStatsSnapshot(names []string) ([]ContainerStats, error) {
+	reports, err := containers.Stats(p.ctx, names, &containers.StatsOptions{Stream: boolPtr(false)})
+	if err != nil {
+		return nil, err
+	}
+	// Non-streamed stats send a single report before closing the channel
+	report := <-reports
+	if report.Error != nil {
+		return nil, report.Error
+	}
+	stats := make([]ContainerStats, 0, len(report.Stats))
+	for _, s := range report.Stats {
+		stat := ContainerStats{
+			ID:          s.ContainerID,
+			Name:        s.Name,
+			CPUPercent:  s.CPU,
+			CPUNano:     s.CPUNano,
+			SystemNano:  s.SystemNano,
+			MemUsage:    s.MemUsage,
+			MemLimit:    s.MemLimit,
+			MemPercent:  s.MemPerc,
+			BlockInput:  s.BlockInput,
+			BlockOutput: s.BlockOutput,
+			PIDs:        s.PIDs,
+		}
+		for _, n := range s.Network {
+			stat.NetInput += n.RxBytes
+			stat.NetOutput += n.TxBytes
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
 }
 
 // ContainerStop stops a running container.
@@ -266,21 +644,151 @@ func (p *podmanApi) ContainerStop(name string) (string, error) {
 	return name, nil
 }
 
+// ContainerTop returns the running processes of a container.
+func (p *podmanApi) ContainerTop(name string, psArgs []string) (string, error) {
+	opts := &containers.TopOptions{}
+	if len(psArgs) > 0 {
+		opts.Descriptors = &psArgs
+	}
+	rows, err := containers.Top(p.ctx, name, opts)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return formatTop(nil, nil, p.outputFormat)
+	}
+	// The first row contains the titles, cells are separated by tabs
+	processes := make([][]string, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		processes = append(processes, strings.Split(row, "\t"))
+	}
+	return formatTop(strings.Split(rows[0], "\t"), processes, p.outputFormat)
+}
+
+// ContainerUnpause resumes all the processes in a paused container.
+func (p *podmanApi) ContainerUnpause(name string) (string, error) {
+	if err := containers.Unpause(p.ctx, name, nil); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// ContainerUpdate changes the resource limits and restart policy of a container.
+func (p *podmanApi) ContainerUpdate(name string, opts ContainerUpdateOptions) (string, error) {
+	resources, err := newUpdateResources(opts)
+	if err != nil {
+		return "", err
+	}
+	updateOpts := &entitiesTypes.ContainerUpdateOptions{
+		NameOrID:  name,
+		Resources: resources,
+	}
+	if opts.RestartPolicy != "" {
+		policy, retries, err := parseRestartPolicy(opts.RestartPolicy)
+		if err != nil {
+			return "", err
+		}
+		updateOpts.RestartPolicy = &policy
+		updateOpts.RestartRetries = retries
+	}
+	return containers.Update(p.ctx, updateOpts)
+}
+
+// ContainerWait waits for a container to meet one of the conditions.
+func (p *podmanApi) ContainerWait(ctx context.Context, name string, conditions []string, timeout time.Duration) (*WaitResult, error) {
+	// The bindings connection is stored in p.ctx, stop waiting when the caller's context is done too.
+	waitCtx, cancel := context.WithCancel(p.ctx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+	waitCtx, cancelTimeout := withWaitTimeout(waitCtx, timeout)
+	defer cancelTimeout()
+	exitCode, err := containers.Wait(waitCtx, name, &containers.WaitOptions{Conditions: conditions})
+	if err != nil {
+		if waitCtx.Err() != nil {
+			return nil, waitError(waitCtx, name, timeout)
+		}
+		return nil, err
+	}
+	result := &WaitResult{ExitCode: int(exitCode)}
+	// The container may no longer exist (e.g. started with --rm)
+	if data, err := containers.Inspect(p.ctx, name, nil); err == nil && data.State != nil {
+		result.State = data.State.Status
+	}
+	return result, nil
+}
+
 // ImageBuild builds an image from a Containerfile.
-func (p *podmanApi) ImageBuild(containerFile string, imageName string) (string, error) {
-	contextDir := filepath.Dir(containerFile)
-	opts := entitiesTypes.BuildOptions{
+func (p *podmanApi) ImageBuild(ctx context.Context, opts ImageBuildOptions, onLine func(line string)) (string, error) {
+	buildArgs, err := opts.buildArgs()
+	if err != nil {
+		return "", err
+	}
+	opts, cleanup, err := prepareBuildContext(opts)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	buildOpts := entitiesTypes.BuildOptions{
 		BuildOptions: buildahDefine.BuildOptions{
-			ContextDirectory: contextDir,
-			Output:           imageName,
+			ContextDirectory: opts.contextDir(),
+			Args:             buildArgs,
+			Target:           opts.Target,
+			Labels:           opts.Labels,
+			NoCache:          opts.NoCache,
 			CommonBuildOpts:  &buildahDefine.CommonBuildOptions{},
 		},
 	}
-	report, err := images.Build(p.ctx, []string{containerFile}, opts)
+	if len(opts.Tags) > 0 {
+		buildOpts.Output = opts.Tags[0]
+		buildOpts.AdditionalTags = opts.Tags[1:]
+	}
+	if opts.Pull {
+		buildOpts.PullPolicy = buildahDefine.PullAlways
+	}
+	// The bindings write the build output stream to os.Stdout unless Out is set.
+	output := newBuildLog(onLine)
+	buildOpts.Out = output
+	// The bindings connection is stored in p.ctx, stop the build when the caller's context is done too.
+	buildCtx, cancel := context.WithCancel(p.ctx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+	report, err := images.Build(buildCtx, []string{opts.ContainerFile}, buildOpts)
+	output.flush()
+	if err != nil {
+		return "", err
+	}
+	return output.result(report.ID), nil
+}
+
+// ImageHistory lists the layers of an image with their size and creating instruction.
+func (p *podmanApi) ImageHistory(imageName string) (string, error) {
+	history, err := images.History(p.ctx, imageName, nil)
+	if err != nil {
+		return "", err
+	}
+	layers := make([]imageHistoryLayer, 0, len(history))
+	for _, h := range history {
+		layers = append(layers, imageHistoryLayer{
+			ID:        h.ID,
+			Created:   time.Unix(h.Created, 0),
+			CreatedBy: h.CreatedBy,
+			Tags:      h.Tags,
+			Size:      h.Size,
+			Comment:   h.Comment,
+		})
+	}
+	return formatImageHistory(layers, p.outputFormat)
+}
+
+// ImageInspect displays the low-level information on an image identified by ID or name.
+func (p *podmanApi) ImageInspect(imageName string) (string, error) {
+	data, err := images.GetImage(p.ctx, imageName, nil)
 	if err != nil {
 		return "", err
 	}
-	return report.ID, nil
+	return toJSON(data)
 }
 
 // ImageList lists all images on the system.
@@ -299,6 +807,65 @@ func (p *podmanApi) ImageList() (string, error) {
 	return formatImageList(data), nil
 }
 
+// ImageLoad loads the images from the docker-archive or oci-archive tarball, or oci-dir directory, at the host path.
+func (p *podmanApi) ImageLoad(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	var r io.Reader
+	if info.IsDir() {
+		// The service only loads archives, an archived oci-dir directory is an oci-archive tarball
+		pr, pw := io.Pipe()
+		go func() {
+			_ = pw.CloseWithError(createArchive(pw, path, ""))
+		}()
+		defer func() { _ = pr.Close() }()
+		r = pr
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	report, err := images.Load(p.ctx, r)
+	if err != nil {
+		return "", err
+	}
+	return formatLoadedImages(report.Names), nil
+}
+
+// ImagePrune removes the dangling images (all the unused images if all is set) matching the filters,
+// or only reports them if dryRun is set.
+func (p *podmanApi) ImagePrune(all bool, filters map[string][]string, dryRun bool) (string, error) {
+	listAll := true
+	data, err := images.List(p.ctx, &images.ListOptions{
+		All:     &listAll,
+		Filters: imagePruneFilters(all, filters),
+	})
+	if err != nil {
+		return "", err
+	}
+	candidates := make([]pruneResource, 0, len(data))
+	for _, i := range data {
+		candidates = append(candidates, pruneResource{ID: imagePruneID(i.ID), Name: imagePruneName(i.RepoTags), Size: i.Size})
+	}
+	if dryRun {
+		return formatPruneReport("image", candidates, true, p.outputFormat)
+	}
+	reports, err := images.Prune(p.ctx, &images.PruneOptions{All: &all, Filters: filters})
+	if err != nil {
+		return "", err
+	}
+	pruned, err := apiPrunedResources(reports)
+	if err != nil {
+		return "", err
+	}
+	return formatPruneReport("image", prunedResources(candidates, pruned), false, p.outputFormat)
+}
+
 // ImagePull pulls an image from a registry.
 func (p *podmanApi) ImagePull(imageName string) (string, error) {
 	pulledImages, resolvedName, err := p.pullImageWithShortNameRetry(imageName)
@@ -316,6 +883,10 @@ func (p *podmanApi) ImagePull(imageName string) (string, error) {
 func (p *podmanApi) ImagePush(imageName string) (string, error) {
 	quiet := true
 	opts := &images.PushOptions{Quiet: &quiet}
+	// The bindings send the credentials of the auth file for the registry
+	if p.authFile != "" {
+		opts.Authfile = &p.authFile
+	}
 	if err := images.Push(p.ctx, imageName, imageName, opts); err != nil {
 		return "", err
 	}
@@ -334,6 +905,68 @@ func (p *podmanApi) ImageRemove(imageName string) (string, error) {
 	return "", nil
 }
 
+// ImageSave saves the images to the host path in the docker-archive (default), oci-archive or oci-dir format.
+func (p *podmanApi) ImageSave(imageNames []string, path string, format string) error {
+	format, err := imageSaveFormat(imageNames, format)
+	if err != nil {
+		return err
+	}
+	opts := new(images.ExportOptions).WithFormat(format)
+	if format != imageFormatOCIDir {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		if err = images.Export(p.ctx, imageNames, f, opts); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	}
+	// The service sends the oci-dir directory as a tar archive
+	if err = createImageDir(path); err != nil {
+		return err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(images.Export(p.ctx, imageNames, pw, opts))
+	}()
+	err = extractArchive(pr, path, "")
+	// Unblock the export download if the extraction finished early
+	_ = pr.CloseWithError(err)
+	return err
+}
+
+// ImageTag adds the target name to the source image.
+func (p *podmanApi) ImageTag(source string, target string) (string, error) {
+	repo, tag, err := repoAndTag(target)
+	if err != nil {
+		return "", err
+	}
+	if err = images.Tag(p.ctx, source, tag, repo, nil); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s tagged as %s", source, target), nil
+}
+
+// ImageUntag removes the tag from the image, or all its tags if empty.
+func (p *podmanApi) ImageUntag(imageName string, tag string) (string, error) {
+	if tag == "" {
+		if err := images.Untag(p.ctx, imageName, "", "", nil); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("All tags removed from %s", imageName), nil
+	}
+	repo, repoTag, err := repoAndTag(tag)
+	if err != nil {
+		return "", err
+	}
+	if err = images.Untag(p.ctx, imageName, repoTag, repo, nil); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s removed from %s", tag, imageName), nil
+}
+
 // NetworkList lists all networks on the system.
 func (p *podmanApi) NetworkList() (string, error) {
 	data, err := network.List(p.ctx, nil)
@@ -346,6 +979,56 @@ func (p *podmanApi) NetworkList() (string, error) {
 	return formatNetworkList(data), nil
 }
 
+// RegistryListLogins lists the registries with credentials stored in the auth file and their usernames.
+func (p *podmanApi) RegistryListLogins() (string, error) {
+	logins, err := listRegistryLogins(p.authFile)
+	if err != nil {
+		return "", err
+	}
+	return formatRegistryLogins(logins, p.outputFormat)
+}
+
+// RegistryLogin verifies the credentials against the registry and stores them in the auth file.
+// As in podman --remote login, the registry is contacted by the client and not by the Podman service.
+func (p *podmanApi) RegistryLogin(registry string, opts RegistryLoginOptions) (string, error) {
+	sys := &imageTypes.SystemContext{}
+	if opts.SkipTLSVerify {
+		sys.DockerInsecureSkipTLSVerify = imageTypes.OptionalBoolTrue
+	}
+	var out bytes.Buffer
+	err := auth.Login(p.ctx, sys, &auth.LoginOptions{
+		AuthFile:           p.authFile,
+		Username:           opts.Username,
+		Password:           opts.Password,
+		AcceptRepositories: true,
+		Stdin:              strings.NewReader(""),
+		Stdout:             &out,
+	}, []string{registry})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// RegistryLogout removes the credentials of the registry from the auth file, or of all the registries if all is set.
+func (p *podmanApi) RegistryLogout(registry string, all bool) (string, error) {
+	var args []string
+	if !all {
+		args = []string{registry}
+	}
+	var out bytes.Buffer
+	err := auth.Logout(&imageTypes.SystemContext{}, &auth.LogoutOptions{
+		AuthFile:           p.authFile,
+		All:                all,
+		AcceptRepositories: true,
+		Stdout:             &out,
+	}, args)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
 // VolumeList lists all volumes on the system.
 func (p *podmanApi) VolumeList() (string, error) {
 	data, err := volumes.List(p.ctx, nil)
@@ -358,13 +1041,35 @@ func (p *podmanApi) VolumeList() (string, error) {
 	return formatVolumeList(data), nil
 }
 
-// toJSON converts a value to an indented JSON string.
-func toJSON(v any) (string, error) {
-	data, err := json.MarshalIndent(v, "", "  ")
+// repoAndTag splits the image name into the repository and tag (latest if not specified) of the tag and untag endpoints.
+func repoAndTag(imageName string) (string, string, error) {
+	ref, err := reference.Parse(imageName)
 	if err != nil {
-		return "", err
+		return "", "", fmt.Errorf("invalid image name %s: %w", imageName, err)
+	}
+	named, ok := ref.(reference.Named)
+	if !ok {
+		return "", "", fmt.Errorf("invalid image name %s: repository name is missing", imageName)
 	}
-	return string(data), nil
+	tag := "latest"
+	if tagged, ok := ref.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	}
+	return named.Name(), tag, nil
+}
+
+// apiPrunedResources returns the resources removed according to the prune reports, or the errors of the reports if any.
+func apiPrunedResources(reports []*entitiesReports.PruneReport) ([]pruneResource, error) {
+	var pruned []pruneResource
+	var errs []error
+	for _, r := range reports {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove %s: %w", r.Id, r.Err))
+			continue
+		}
+		pruned = append(pruned, pruneResource{ID: r.Id, Size: int64(r.Size)})
+	}
+	return pruned, errors.Join(errs...)
 }
 
 // formatContainerList formats container list data as a text table.
@@ -493,25 +1198,6 @@ func formatTimeAgo(t time.Time) string {
 	}
 }
 
-// formatSize formats a size in bytes as a human-readable string.
-func formatSize(size int64) string {
-	const (
-		KB = 1000
-		MB = 1000 * KB
-		GB = 1000 * MB
-	)
-	switch {
-	case size >= GB:
-		return fmt.Sprintf("%.1f GB", float64(size)/float64(GB))
-	case size >= MB:
-		return fmt.Sprintf("%.1f MB", float64(size)/float64(MB))
-	case size >= KB:
-		return fmt.Sprintf("%.1f KB", float64(size)/float64(KB))
-	default:
-		return fmt.Sprintf("%d B", size)
-	}
-}
-
 // formatPorts formats port mappings as a string.
 func formatPorts(ports []netTypes.PortMapping) string {
 	if len(ports) == 0 {
@@ -528,11 +1214,192 @@ func formatPorts(ports []netTypes.PortMapping) string {
 	return strings.Join(parts, ", ")
 }
 
+// newSpecGenerator maps ContainerRunOptions onto a SpecGenerator, mirroring the `podman run` flags.
+func newSpecGenerator(opts ContainerRunOptions) (*specgen.SpecGenerator, error) {
+	s := specgen.NewSpecGenerator(opts.Image, false)
+	s.Name = opts.Name
+	s.Remove = boolPtr(opts.AutoRemove) // --rm
+
+	// Port mappings
+	if len(opts.Ports) > 0 || len(opts.PortMappings) > 0 {
+		for hostPort, containerPort := range opts.Ports {
+			s.PortMappings = append(s.PortMappings, netTypes.PortMapping{
+				HostPort:      uint16(hostPort),
+				ContainerPort: uint16(containerPort),
+				Protocol:      "tcp",
+			})
+		}
+		for _, m := range opts.PortMappings {
+			s.PortMappings = append(s.PortMappings, netTypes.PortMapping{
+				HostIP:        m.HostIP,
+				HostPort:      uint16(m.HostPort),
+				ContainerPort: uint16(m.ContainerPort),
+				Protocol:      cmp.Or(m.Protocol, "tcp"),
+			})
+		}
+	} else {
+		s.PublishExposedPorts = boolPtr(true) // --publish-all
+	}
+
+	// Environment variables (key=value format → map)
+	if len(opts.Env) > 0 {
+		s.Env = keyValuesToMap(opts.Env)
+	}
+
+	// Labels (key=value format → map)
+	if len(opts.Labels) > 0 {
+		s.Labels = keyValuesToMap(opts.Labels)
+	}
+
+	// Volumes (bind mounts, named volumes and overlay volumes)
+	if len(opts.Volumes) > 0 {
+		mounts, volumes, overlayVolumes, err := specgen.GenVolumeMounts(opts.Volumes)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range mounts {
+			s.Mounts = append(s.Mounts, m)
+		}
+		for _, v := range volumes {
+			s.Volumes = append(s.Volumes, v)
+		}
+		for _, v := range overlayVolumes {
+			s.OverlayVolumes = append(s.OverlayVolumes, v)
+		}
+	}
+
+	// Network
+	if opts.Network != "" {
+		netNS, networks, networkOptions, err := specgen.ParseNetworkFlag([]string{opts.Network})
+		if err != nil {
+			return nil, err
+		}
+		s.NetNS = netNS
+		s.Networks = networks
+		s.NetworkOptions = networkOptions
+	}
+
+	s.Command = opts.Command
+	s.Entrypoint = opts.Entrypoint
+	s.WorkDir = opts.WorkDir
+	s.User = opts.User
+
+	// Resource limits
+	if opts.Memory != "" || opts.CPUs > 0 {
+		s.ResourceLimits = &specs.LinuxResources{}
+	}
+	if opts.Memory != "" {
+		memory, err := parseMemory("memory limit", opts.Memory)
+		if err != nil {
+			return nil, err
+		}
+		s.ResourceLimits.Memory = &specs.LinuxMemory{Limit: &memory}
+	}
+	if opts.CPUs > 0 {
+		period := uint64(100000)
+		quota := int64(opts.CPUs * float64(period))
+		s.ResourceLimits.CPU = &specs.LinuxCPU{Period: &period, Quota: &quota}
+	}
+
+	// Restart policy (policy[:max-retries])
+	if opts.RestartPolicy != "" {
+		policy, retries, err := parseRestartPolicy(opts.RestartPolicy)
+		if err != nil {
+			return nil, err
+		}
+		s.RestartPolicy = policy
+		s.RestartRetries = retries
+	}
+	return s, nil
+}
+
+// newUpdateResources converts the update options to the Linux resources to change, nil if no resource is changed.
+func newUpdateResources(opts ContainerUpdateOptions) (*specs.LinuxResources, error) {
+	resources := &specs.LinuxResources{}
+	if opts.Memory != "" || opts.MemorySwap != "" {
+		resources.Memory = &specs.LinuxMemory{}
+	}
+	if opts.Memory != "" {
+		memory, err := parseMemory("memory limit", opts.Memory)
+		if err != nil {
+			return nil, err
+		}
+		resources.Memory.Limit = &memory
+	}
+	if opts.MemorySwap != "" {
+		swap, err := parseMemory("memory swap limit", opts.MemorySwap)
+		if err != nil {
+			return nil, err
+		}
+		resources.Memory.Swap = &swap
+	}
+	if opts.CPUQuota != 0 || opts.CPUPeriod != 0 || opts.CPUShares != 0 || opts.CPUSetCPUs != "" {
+		resources.CPU = &specs.LinuxCPU{Cpus: opts.CPUSetCPUs}
+	}
+	if opts.CPUQuota != 0 {
+		resources.CPU.Quota = &opts.CPUQuota
+	}
+	if opts.CPUPeriod != 0 {
+		resources.CPU.Period = &opts.CPUPeriod
+	}
+	if opts.CPUShares != 0 {
+		resources.CPU.Shares = &opts.CPUShares
+	}
+	if opts.PidsLimit != 0 {
+		resources.Pids = &specs.LinuxPids{Limit: opts.PidsLimit}
+	}
+	if resources.Memory == nil && resources.CPU == nil && resources.Pids == nil {
+		return nil, nil
+	}
+	return resources, nil
+}
+
+// parseMemory parses a memory size with an optional unit suffix (b, k, m, g), -1 means unlimited.
+func parseMemory(name string, value string) (int64, error) {
+	if value == "-1" {
+		return -1, nil
+	}
+	memory, err := units.RAMInBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return memory, nil
+}
+
+// parseRestartPolicy parses a policy[:max-retries] restart policy.
+func parseRestartPolicy(restartPolicy string) (string, *uint, error) {
+	policy, retries, hasRetries := strings.Cut(restartPolicy, ":")
+	if !hasRetries {
+		return policy, nil, nil
+	}
+	n, err := strconv.ParseUint(retries, 10, 32)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid restart policy %q: %w", restartPolicy, err)
+	}
+	maxRetries := uint(n)
+	return policy, &maxRetries, nil
+}
+
+// keyValuesToMap converts a list of <key>=<value> strings into a map, ignoring malformed entries.
+func keyValuesToMap(keyValues []string) map[string]string {
+	result := make(map[string]string, len(keyValues))
+	for _, kv := range keyValues {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
 // pullImageWithShortNameRetry pulls an image, retrying with docker.io/ prefix on short-name errors.
 // Returns the pulled images, the resolved image name (which may have docker.io/ prepended), and any error.
 func (p *podmanApi) pullImageWithShortNameRetry(imageName string) ([]string, string, error) {
 	quiet := true
 	opts := &images.PullOptions{Quiet: &quiet}
+	// The bindings send the credentials of the auth file for the registry
+	if p.authFile != "" {
+		opts.Authfile = &p.authFile
+	}
 	pulledImages, err := images.Pull(p.ctx, imageName, opts)
 	if err == nil {
 		return pulledImages, imageName, nil
@@ -545,6 +1412,123 @@ func (p *podmanApi) pullImageWithShortNameRetry(imageName string) ([]string, str
 	return nil, imageName, err
 }
 
+// createWithShortNameRetry creates a container from the spec, retrying with the docker.io/ prefixed image on short-name errors.
+// Returns the ID of the created container.
+func (p *podmanApi) createWithShortNameRetry(s *specgen.SpecGenerator, imageName string) (string, error) {
+	createResponse, err := containers.CreateWithSpec(p.ctx, s, nil)
+	if err != nil && strings.Contains(err.Error(), "short-name") {
+		s.Image = "docker.io/" + imageName
+		createResponse, err = containers.CreateWithSpec(p.ctx, s, nil)
+	}
+	if err != nil {
+		return "", err
+	}
+	return createResponse.ID, nil
+}
+
+// toBindingsLogOptions converts LogOptions to the bindings representation.
+func toBindingsLogOptions(opts LogOptions) *containers.LogOptions {
+	logOpts := &containers.LogOptions{
+		Stdout:     boolPtr(opts.Stdout),
+		Stderr:     boolPtr(opts.Stderr),
+		Timestamps: boolPtr(opts.Timestamps),
+	}
+	if opts.Tail > 0 {
+		tail := strconv.Itoa(opts.Tail)
+		logOpts.Tail = &tail
+	}
+	if opts.Since != "" {
+		logOpts.Since = &opts.Since
+	}
+	if opts.Until != "" {
+		logOpts.Until = &opts.Until
+	}
+	return logOpts
+}
+
+// streamLogs passes the container log entries to onLine in arrival order until the
+// stream ends, ctx is done or onLine returns false.
+func streamLogs(ctx context.Context, name string, logOpts *containers.LogOptions, onLine func(line string) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// containers.Logs sends each frame to the channels but never closes them,
+	// the channels are closed once it returns (EOF or error).
+	stdoutChan := make(chan string)
+	stderrChan := make(chan string)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- containers.Logs(ctx, name, logOpts, stdoutChan, stderrChan)
+		close(stdoutChan)
+		close(stderrChan)
+	}()
+
+	// Keep draining after onLine stops the stream so that containers.Logs never blocks.
+	stopped := false
+	for stdoutChan != nil || stderrChan != nil {
+		var line string
+		var ok bool
+		select {
+		case line, ok = <-stdoutChan:
+			if !ok {
+				stdoutChan = nil
+				continue
+			}
+		case line, ok = <-stderrChan:
+			if !ok {
+				stderrChan = nil
+				continue
+			}
+		}
+		if !stopped && !onLine(line) {
+			stopped = true
+			cancel()
+		}
+	}
+
+	if err := <-errChan; err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to get container logs: %w", err)
+	}
+	return nil
+}
+
+// collectOutput returns the complete stdout and stderr logs of a container separately.
+func collectOutput(ctx context.Context, name string) (string, string, error) {
+	// containers.Logs sends each frame to the channels but never closes them,
+	// the channels are closed once it returns (EOF or error).
+	stdoutChan := make(chan string)
+	stderrChan := make(chan string)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- containers.Logs(ctx, name, toBindingsLogOptions(LogOptions{Stdout: true, Stderr: true}), stdoutChan, stderrChan)
+		close(stdoutChan)
+		close(stderrChan)
+	}()
+
+	var stdout, stderr strings.Builder
+	for stdoutChan != nil || stderrChan != nil {
+		select {
+		case line, ok := <-stdoutChan:
+			if !ok {
+				stdoutChan = nil
+				continue
+			}
+			stdout.WriteString(line)
+		case line, ok := <-stderrChan:
+			if !ok {
+				stderrChan = nil
+				continue
+			}
+			stderr.WriteString(line)
+		}
+	}
+
+	if err := <-errChan; err != nil {
+		return "", "", fmt.Errorf("failed to get container logs: %w", err)
+	}
+	return stdout.String(), stderr.String(), nil
+}
+
 // boolPtr returns a pointer to the given bool value.
 func boolPtr(v bool) *bool {
 	return &v
@@ -1,10 +1,24 @@
 package podman
 
 import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net"
+	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/manusa/podman-mcp-server/pkg/config"
 )
@@ -16,6 +30,7 @@ func init() {
 type podmanCli struct {
 	filePath     string
 	outputFormat string
+	authFile     string
 }
 
 // Name returns the unique identifier for this implementation.
@@ -51,6 +66,7 @@ func (p *podmanCli) Initialize(cfg config.Config) (Podman, error) {
 	return &podmanCli{
 		filePath:     filePath,
 		outputFormat: cfg.OutputFormat,
+		authFile:     cfg.AuthFile,
 	}, nil
 }
 
@@ -72,16 +88,254 @@ func findBinary() (string, error) {
 	return "", errors.New("podman CLI not found")
 }
 
+// ContainerClone
+// https://docs.podman.io/en/stable/markdown/podman-container-clone.1.html
+// podman container clone isn't supported by remote clients, the container is recreated from its inspect data instead.
+func (p *podmanCli) ContainerClone(name string, opts ContainerCloneOptions) (string, error) {
+	stdout, stderr, err := p.execSeparate("container", "inspect", name)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	var inspect []json.RawMessage
+	if err = json.Unmarshal([]byte(stdout), &inspect); err != nil || len(inspect) == 0 {
+		return "", fmt.Errorf("failed to parse container inspect output: %w", err)
+	}
+	runOpts, err := cloneRunOptions(inspect[0], opts)
+	if err != nil {
+		return "", err
+	}
+	args := slices.Concat([]string{"create"}, publishFlags(runOpts))
+	flags, err := containerRunFlags(runOpts)
+	if err != nil {
+		return "", err
+	}
+	output, err := p.exec(slices.Concat(args, flags, []string{runOpts.Image}, runOpts.Command)...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	// The container ID is printed last, after any image pull progress or warnings
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return "", errors.New("podman create didn't return the container ID")
+	}
+	id := fields[len(fields)-1]
+	if opts.Start {
+		if output, err = p.exec("container", "start", id); err != nil {
+			return "", fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+		}
+	}
+	return id, nil
+}
+
+// ContainerCommit
+// https://docs.podman.io/en/stable/markdown/podman-commit.1.html
+func (p *podmanCli) ContainerCommit(name string, imageName string, message string, author string, changes []string) (string, error) {
+	args := []string{"commit", "--quiet"}
+	if message != "" {
+		// The commit message is only supported by the docker image format
+		args = append(args, "--format", "docker", "--message", message)
+	}
+	if author != "" {
+		args = append(args, "--author", author)
+	}
+	for _, change := range changes {
+		args = append(args, "--change", change)
+	}
+	args = append(args, name, imageName)
+	output, err := p.exec(args...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// ContainerCopyFrom
+// https://docs.podman.io/en/stable/markdown/podman-cp.1.html
+func (p *podmanCli) ContainerCopyFrom(name string, containerPath string, hostPath string) error {
+	if output, err := p.exec("cp", name+":"+containerPath, hostPath); err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return nil
+}
+
+// ContainerCopyTo
+// https://docs.podman.io/en/stable/markdown/podman-cp.1.html
+func (p *podmanCli) ContainerCopyTo(name string, hostPath string, containerPath string) error {
+	if output, err := p.exec("cp", hostPath, name+":"+containerPath); err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return nil
+}
+
+// ContainerDiff
+// https://docs.podman.io/en/stable/markdown/podman-container-diff.1.html
+func (p *podmanCli) ContainerDiff(name string, pathPrefix string) (string, error) {
+	stdout, stderr, err := p.execSeparate("container", "diff", "--format", "json", name)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	var changes containerChanges
+	if err = json.Unmarshal([]byte(stdout), &changes); err != nil {
+		return "", fmt.Errorf("failed to parse container diff: %w", err)
+	}
+	fsChanges := make([]fsChange, 0, len(changes.Changed)+len(changes.Added)+len(changes.Deleted))
+	for kind, paths := range map[byte][]string{'C': changes.Changed, 'A': changes.Added, 'D': changes.Deleted} {
+		for _, path := range paths {
+			fsChanges = append(fsChanges, fsChange{Kind: kind, Path: path})
+		}
+	}
+	return formatDiff(fsChanges, pathPrefix, p.outputFormat)
+}
+
+// ContainerExec
+// https://docs.podman.io/en/stable/markdown/podman-exec.1.html
+func (p *podmanCli) ContainerExec(name string, command []string, env []string, workdir string, user string) (*ExecResult, error) {
+	args := []string{"exec"}
+	for _, e := range env {
+		args = append(args, "--env", e)
+	}
+	if workdir != "" {
+		args = append(args, "--workdir", workdir)
+	}
+	if user != "" {
+		args = append(args, "--user", user)
+	}
+	args = append(append(args, name), command...)
+	stdout, stderr, err := p.execSeparate(args...)
+	result := &ExecResult{Stdout: stdout, Stderr: stderr}
+	var exitErr *exec.ExitError
+	// Exit code 125 means podman itself failed (e.g. no such container),
+	// any other non-zero exit code is returned by the executed command.
+	if errors.As(err, &exitErr) && exitErr.ExitCode() != 125 {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	return result, nil
+}
+
+// ContainerFileList
+// https://docs.podman.io/en/stable/markdown/podman-cp.1.html
+func (p *podmanCli) ContainerFileList(name string, filePath string) (string, error) {
+	// podman cp writes the tar archive of the path to stdout if the destination is -, it's listed as it's streamed
+	var files []containerFile
+	err := p.execStream(func(stdout io.Reader) error {
+		var err error
+		if files, err = listArchive(stdout); err != nil {
+			return fmt.Errorf("failed to parse container archive: %w", err)
+		}
+		return nil
+	}, "cp", name+":"+filePath, "-")
+	if err != nil {
+		return "", err
+	}
+	return formatFileList(files, p.outputFormat)
+}
+
+// ContainerFileRead
+// https://docs.podman.io/en/stable/markdown/podman-cp.1.html
+func (p *podmanCli) ContainerFileRead(name string, filePath string, maxSize int64) ([]byte, error) {
+	// podman cp writes the tar archive of the (symlink resolved) path to stdout if the destination is -,
+	// the file is checked with its tar header so that directories and large files aren't transferred
+	var content []byte
+	found := false
+	err := p.execStream(func(stdout io.Reader) error {
+		var err error
+		content, err = readArchiveFile(stdout, filePath, maxSize)
+		if errors.Is(err, io.EOF) {
+			// Empty archive, podman failed to copy the path
+			return nil
+		}
+		found = err == nil
+		return err
+	}, "cp", name+":"+filePath, "-")
+	if err == nil && !found {
+		err = fmt.Errorf("failed to read %s from container %s", filePath, name)
+	}
+	return content, err
+}
+
+// ContainerFileWrite
+// https://docs.podman.io/en/stable/markdown/podman-cp.1.html
+func (p *podmanCli) ContainerFileWrite(name string, filePath string, content []byte) error {
+	tmpDir, err := os.MkdirTemp("", "podman-mcp-server-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	// Same base name as the destination so that it's also copied with that name into an existing directory
+	tmpFile := filepath.Join(tmpDir, path.Base(filePath))
+	if err = os.WriteFile(tmpFile, content, 0o644); err != nil {
+		return err
+	}
+	if output, err := p.exec("cp", tmpFile, name+":"+filePath); err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return nil
+}
+
+// ContainerHealth
+// https://docs.podman.io/en/stable/markdown/podman-inspect.1.html
+func (p *podmanCli) ContainerHealth(name string, logs int) (string, error) {
+	out, err := p.ContainerInspect(name)
+	if err != nil {
+		return "", err
+	}
+	var inspect []struct {
+		State struct {
+			Health *ContainerHealth
+		}
+	}
+	if err = json.Unmarshal([]byte(out), &inspect); err != nil {
+		return "", fmt.Errorf("failed to parse container inspect output: %w", err)
+	}
+	if len(inspect) == 0 {
+		return "", fmt.Errorf("no such container %s", name)
+	}
+	return formatHealth(name, inspect[0].State.Health, logs, p.outputFormat)
+}
+
+// ContainerHealthcheckRun
+// https://docs.podman.io/en/stable/markdown/podman-healthcheck-run.1.html
+func (p *podmanCli) ContainerHealthcheckRun(name string) (string, error) {
+	stdout, stderr, err := p.execSeparate("healthcheck", "run", name)
+	status := strings.TrimSpace(stdout)
+	// podman prints the status and exits with 1 if the container isn't healthy (starting, unhealthy or stopped)
+	if err != nil && (status == "" || strings.TrimSpace(stderr) != "") {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	if status == "" {
+		status = "healthy"
+	}
+	return status, nil
+}
+
 // ContainerInspect
 // https://docs.podman.io/en/stable/markdown/podman-inspect.1.html
 func (p *podmanCli) ContainerInspect(name string) (string, error) {
 	return p.exec("inspect", name)
 }
 
+// ContainerKill
+// https://docs.podman.io/en/stable/markdown/podman-kill.1.html
+func (p *podmanCli) ContainerKill(name string, signal string) (string, error) {
+	args := []string{"container", "kill"}
+	if signal != "" {
+		args = append(args, "--signal", signal)
+	}
+	return p.exec(append(args, name)...)
+}
+
 // ContainerList
 // https://docs.podman.io/en/stable/markdown/podman-ps.1.html
-func (p *podmanCli) ContainerList() (string, error) {
-	args := []string{"container", "list", "-a"}
+func (p *podmanCli) ContainerList(opts ContainerListOptions) (string, error) {
+	args := []string{"container", "list"}
+	if opts.All {
+		args = append(args, "-a")
+	}
+	args = append(args, filterFlags(opts.filters())...)
 	if p.outputFormat == config.OutputFormatJSON {
 		args = append(args, "--format", "json")
 	}
@@ -90,8 +344,121 @@ func (p *podmanCli) ContainerList() (string, error) {
 
 // ContainerLogs
 // https://docs.podman.io/en/stable/markdown/podman-logs.1.html
-func (p *podmanCli) ContainerLogs(name string) (string, error) {
-	return p.exec("logs", name)
+func (p *podmanCli) ContainerLogs(name string, opts LogOptions) (string, error) {
+	args := slices.Concat([]string{"logs"}, logsFlags(opts), []string{name})
+	if opts.Stdout && opts.Stderr {
+		return p.exec(args...)
+	}
+	// podman logs has no stream selection flags, the container streams are
+	// written to the corresponding process streams instead.
+	stdout, stderr, err := p.execSeparate(args...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	if opts.Stderr {
+		return stderr, nil
+	}
+	return stdout, nil
+}
+
+// maxLogLineSize is the maximum size of a followed log line, the follow fails if a line exceeds it.
+const maxLogLineSize = 1 << 20
+
+// ContainerLogsFollow
+// https://docs.podman.io/en/stable/markdown/podman-logs.1.html
+func (p *podmanCli) ContainerLogsFollow(ctx context.Context, name string, opts LogOptions, onLine func(line string) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	args := slices.Concat([]string{"logs", "--follow"}, logsFlags(opts), []string{name})
+	cmd := exec.CommandContext(ctx, p.filePath, args...)
+	// Don't wait for orphaned subprocesses holding the output open once the process is killed.
+	cmd.WaitDelay = time.Second
+	pr, pw := io.Pipe()
+	var stderr bytes.Buffer
+	cmd.Stdout = io.Discard
+	if opts.Stdout {
+		cmd.Stdout = pw
+	}
+	cmd.Stderr = &stderr
+	if opts.Stderr {
+		cmd.Stderr = io.MultiWriter(pw, &stderr)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		_ = pw.Close()
+		waitErr <- err
+	}()
+
+	stopped := false
+	scanner := bufio.NewScanner(pr)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		if !stopped && !onLine(scanner.Text()+"\n") {
+			stopped = true
+			cancel()
+		}
+	}
+	scanErr := scanner.Err()
+	if scanErr != nil {
+		cancel()
+	}
+	// Drain any remaining output so that the process can exit.
+	_, _ = io.Copy(io.Discard, pr)
+
+	err := <-waitErr
+	if scanErr != nil {
+		return fmt.Errorf("failed to read the container logs: %w", scanErr)
+	}
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+	return nil
+}
+
+// ContainerPause
+// https://docs.podman.io/en/stable/markdown/podman-pause.1.html
+func (p *podmanCli) ContainerPause(name string) (string, error) {
+	return p.exec("container", "pause", name)
+}
+
+// ContainerPort
+// https://docs.podman.io/en/stable/markdown/podman-port.1.html
+func (p *podmanCli) ContainerPort(name string) (string, error) {
+	stdout, stderr, err := p.execSeparate("port", name)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	bindings, err := parsePortBindings(stdout)
+	if err != nil {
+		return "", err
+	}
+	return formatPortBindings(bindings, p.outputFormat)
+}
+
+// ContainerPrune
+// https://docs.podman.io/en/stable/markdown/podman-container-prune.1.html
+func (p *podmanCli) ContainerPrune(filters map[string][]string, dryRun bool) (string, error) {
+	listArgs := slices.Concat([]string{"container", "list", "-a", "--size", "--format", "json"}, filterFlags(containerPruneFilters(filters)))
+	stdout, stderr, err := p.execSeparate(listArgs...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	candidates, err := parseContainerPruneCandidates(stdout)
+	if err != nil {
+		return "", err
+	}
+	if dryRun {
+		return formatPruneReport("container", candidates, true, p.outputFormat)
+	}
+	stdout, stderr, err = p.execSeparate(slices.Concat([]string{"container", "prune", "--force"}, filterFlags(filters))...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	return formatPruneReport("container", prunedResources(candidates, parsePruneOutput(stdout)), false, p.outputFormat)
 }
 
 // ContainerRemove
@@ -100,31 +467,217 @@ func (p *podmanCli) ContainerRemove(name string) (string, error) {
 	return p.exec("container", "rm", name)
 }
 
+// ContainerRename
+// https://docs.podman.io/en/stable/markdown/podman-rename.1.html
+func (p *podmanCli) ContainerRename(name string, newName string) (string, error) {
+	if output, err := p.exec("rename", name, newName); err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return newName, nil
+}
+
+// ContainerRestart
+// https://docs.podman.io/en/stable/markdown/podman-restart.1.html
+func (p *podmanCli) ContainerRestart(name string) (string, error) {
+	return p.exec("container", "restart", name)
+}
+
 // ContainerRun
 // https://docs.podman.io/en/stable/markdown/podman-run.1.html
-func (p *podmanCli) ContainerRun(imageName string, portMappings map[int]int, envVariables []string) (string, error) {
-	args := []string{"run", "--rm", "-d"}
-	if len(portMappings) > 0 {
-		for hostPort, containerPort := range portMappings {
-			args = append(args, fmt.Sprintf("--publish=%d:%d", hostPort, containerPort))
-		}
+func (p *podmanCli) ContainerRun(opts ContainerRunOptions) (string, error) {
+	args := []string{"run", "-d"}
+	if opts.AutoRemove {
+		args = append(args, "--rm")
+	}
+	if publish := publishFlags(opts); len(publish) > 0 {
+		args = append(args, publish...)
 	} else {
 		args = append(args, "--publish-all")
 	}
-	for _, env := range envVariables {
-		args = append(args, "--env", env)
+	flags, err := containerRunFlags(opts)
+	if err != nil {
+		return "", err
+	}
+	output, err := execWithShortNameRetry(opts.Image, func(image string) (string, error) {
+		return p.exec(slices.Concat(args, flags, []string{image}, opts.Command)...)
+	})
+	if err != nil {
+		return "", err
 	}
-	output, err := p.exec(append(args, imageName)...)
-	if err == nil {
-		return output, nil
+	return output, nil
+}
+
+// ContainerRunCommand
+// https://docs.podman.io/en/stable/markdown/podman-create.1.html
+func (p *podmanCli) ContainerRunCommand(ctx context.Context, opts ContainerRunOptions, timeout time.Duration) (*ExecResult, error) {
+	flags, err := containerRunFlags(opts)
+	if err != nil {
+		return nil, err
 	}
-	if strings.Contains(output, "Error: short-name") {
-		imageName = "docker.io/" + imageName
-		if output, err = p.exec(append(args, imageName)...); err == nil {
-			return output, nil
+	output, err := execWithShortNameRetry(opts.Image, func(image string) (string, error) {
+		return p.exec(slices.Concat([]string{"create", "--quiet"}, flags, []string{image}, opts.Command)...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	// The container ID is printed last, after any warnings
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return nil, errors.New("podman create didn't return the container ID")
+	}
+	id := shortID(fields[len(fields)-1])
+	// The container is removed once its output is collected, or killed if the timeout expires
+	defer func() { _, _ = p.exec("container", "rm", "--force", "--volumes", "--time", "0", id) }()
+	if output, err = p.exec("container", "start", id); err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	result, err := p.ContainerWait(ctx, id, nil, timeout)
+	if err != nil {
+		return nil, err
+	}
+	// podman logs writes the container stdout and stderr to its own stdout and stderr
+	stdout, stderr, err := p.execSeparate("logs", id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	return &ExecResult{Stdout: stdout, Stderr: stderr, ExitCode: result.ExitCode}, nil
+}
+
+// publishFlags returns the --publish flags of the ports and port mappings of the options.
+func publishFlags(opts ContainerRunOptions) []string {
+	var flags []string
+	for hostPort, containerPort := range opts.Ports {
+		flags = append(flags, fmt.Sprintf("--publish=%d:%d", hostPort, containerPort))
+	}
+	for _, m := range opts.PortMappings {
+		hostAddress := strconv.Itoa(m.HostPort)
+		if m.HostIP != "" {
+			hostAddress = net.JoinHostPort(m.HostIP, hostAddress)
 		}
+		flags = append(flags, fmt.Sprintf("--publish=%s:%d/%s", hostAddress, m.ContainerPort, cmp.Or(m.Protocol, "tcp")))
 	}
-	return "", err
+	return flags
+}
+
+// containerRunFlags returns the podman run/create flags for the options, except for the port publishing and removal flags.
+func containerRunFlags(opts ContainerRunOptions) ([]string, error) {
+	var flags []string
+	if opts.Name != "" {
+		flags = append(flags, "--name", opts.Name)
+	}
+	for _, env := range opts.Env {
+		flags = append(flags, "--env", env)
+	}
+	for _, volume := range opts.Volumes {
+		flags = append(flags, "--volume", volume)
+	}
+	if opts.Network != "" {
+		flags = append(flags, "--network", opts.Network)
+	}
+	if len(opts.Entrypoint) > 0 {
+		// A JSON array is required to override the entrypoint with multiple arguments
+		entrypoint, err := json.Marshal(opts.Entrypoint)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, "--entrypoint", string(entrypoint))
+	}
+	if opts.WorkDir != "" {
+		flags = append(flags, "--workdir", opts.WorkDir)
+	}
+	if opts.User != "" {
+		flags = append(flags, "--user", opts.User)
+	}
+	for _, label := range opts.Labels {
+		flags = append(flags, "--label", label)
+	}
+	if opts.Memory != "" {
+		flags = append(flags, "--memory", opts.Memory)
+	}
+	if opts.CPUs > 0 {
+		flags = append(flags, "--cpus", strconv.FormatFloat(opts.CPUs, 'f', -1, 64))
+	}
+	if opts.RestartPolicy != "" {
+		flags = append(flags, "--restart", opts.RestartPolicy)
+	}
+	return flags, nil
+}
+
+// execWithShortNameRetry runs the podman command for the image, retrying with the docker.io/ prefixed image
+// if podman fails to resolve its short name.
+func execWithShortNameRetry(image string, run func(image string) (string, error)) (string, error) {
+	output, err := run(image)
+	if err != nil && strings.Contains(output, "Error: short-name") {
+		return run("docker.io/" + image)
+	}
+	return output, err
+}
+
+// ContainerStart
+// https://docs.podman.io/en/stable/markdown/podman-start.1.html
+func (p *podmanCli) ContainerStart(name string) (string, error) {
+	return p.exec("container", "start", name)
+}
+
+// ContainerStats
+// https://docs.podman.io/en/stable/markdown/podman-stats.1.html
+func (p *podmanCli) ContainerStats(ctx context.Context, names []string, opts StatsOptions) (string, error) {
+	return collectStats(ctx, names, opts, p.outputFormat, p.containerStatsSnapshot)
+}
+
+// containerStatsSnapshot takes a single resource usage statistics sample of the specified containers.
+// The raw statistics are requested because --format json only provides human-readable values.
+func (p *podmanCli) containerStatsSnapshot(names []string) ([]ContainerStats, error) {
+	args := slices.Concat([]string{"stats", "--no-stream", "--format", "{{json .ContainerStats}}"}, names)
+	stdout, stderr, err := p.execSeparate(args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	var stats []ContainerStats
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if line == "" {
+			continue
+		}
+		var s struct {
+			ContainerID string
+			Name        string
+			CPU         float64
+			CPUNano     uint64
+			SystemNano  uint64
+			MemUsage    uint64
+			MemLimit    uint64
+			MemPerc     float64
+			Network     map[string]struct {
+				RxBytes uint64
+				TxBytes uint64
+			}
+			BlockInput  uint64
+			BlockOutput uint64
+			PIDs        uint64
+		}
+		if err := json.Unmarshal([]byte(line), &s); err != nil {
+			return nil, fmt.Errorf("failed to parse container stats: %w", err)
+		}
+		stat := ContainerStats{
+			ID:          s.ContainerID,
+			Name:        s.Name,
+			CPUPercent:  s.CPU,
+			CPUNano:     s.CPUNano,
+			SystemNano:  s.SystemNano,
+			MemUsage:    s.MemUsage,
+			MemLimit:    s.MemLimit,
+			MemPercent:  s.MemPerc,
+			BlockInput:  s.BlockInput,
+			BlockOutput: s.BlockOutput,
+			PIDs:        s.PIDs,
+		}
+		for _, n := range s.Network {
+			stat.NetInput += n.RxBytes
+			stat.NetOutput += n.TxBytes
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
 }
 
 // ContainerStop
@@ -133,14 +686,152 @@ func (p *podmanCli) ContainerStop(name string) (string, error) {
 	return p.exec("container", "stop", name)
 }
 
+// ContainerTop
+// https://docs.podman.io/en/stable/markdown/podman-top.1.html
+func (p *podmanCli) ContainerTop(name string, psArgs []string) (string, error) {
+	output, err := p.exec(slices.Concat([]string{"top", name}, psArgs)...)
+	if err != nil {
+		return output, err
+	}
+	titles, processes := parseTopTable(output)
+	return formatTop(titles, processes, p.outputFormat)
+}
+
+// ContainerUnpause
+// https://docs.podman.io/en/stable/markdown/podman-unpause.1.html
+func (p *podmanCli) ContainerUnpause(name string) (string, error) {
+	return p.exec("container", "unpause", name)
+}
+
+// ContainerUpdate
+// https://docs.podman.io/en/stable/markdown/podman-update.1.html
+func (p *podmanCli) ContainerUpdate(name string, opts ContainerUpdateOptions) (string, error) {
+	args := []string{"update"}
+	if opts.Memory != "" {
+		args = append(args, "--memory", opts.Memory)
+	}
+	if opts.MemorySwap != "" {
+		args = append(args, "--memory-swap", opts.MemorySwap)
+	}
+	if opts.CPUQuota != 0 {
+		args = append(args, "--cpu-quota", strconv.FormatInt(opts.CPUQuota, 10))
+	}
+	if opts.CPUPeriod != 0 {
+		args = append(args, "--cpu-period", strconv.FormatUint(opts.CPUPeriod, 10))
+	}
+	if opts.CPUShares != 0 {
+		args = append(args, "--cpu-shares", strconv.FormatUint(opts.CPUShares, 10))
+	}
+	if opts.CPUSetCPUs != "" {
+		args = append(args, "--cpuset-cpus", opts.CPUSetCPUs)
+	}
+	if opts.PidsLimit != 0 {
+		args = append(args, "--pids-limit", strconv.FormatInt(opts.PidsLimit, 10))
+	}
+	if opts.RestartPolicy != "" {
+		args = append(args, "--restart", opts.RestartPolicy)
+	}
+	output, err := p.exec(append(args, name)...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return output, nil
+}
+
+// ContainerWait
+// https://docs.podman.io/en/stable/markdown/podman-wait.1.html
+func (p *podmanCli) ContainerWait(ctx context.Context, name string, conditions []string, timeout time.Duration) (*WaitResult, error) {
+	ctx, cancel := withWaitTimeout(ctx, timeout)
+	defer cancel()
+	args := []string{"wait"}
+	for _, condition := range conditions {
+		args = append(args, "--condition", condition)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.filePath, append(args, name)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, waitError(ctx, name, timeout)
+		}
+		return nil, fmt.Errorf("%s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+	exitCode, err := strconv.Atoi(strings.TrimSpace(stdout.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse container exit code: %w", err)
+	}
+	result := &WaitResult{ExitCode: exitCode}
+	// The container may no longer exist (e.g. started with --rm)
+	if state, _, err := p.execSeparate("container", "inspect", "--format", "{{.State.Status}}", name); err == nil {
+		result.State = strings.TrimSpace(state)
+	}
+	return result, nil
+}
+
 // ImageBuild
 // https://docs.podman.io/en/stable/markdown/podman-build.1.html
-func (p *podmanCli) ImageBuild(containerFile string, imageName string) (string, error) {
+func (p *podmanCli) ImageBuild(ctx context.Context, opts ImageBuildOptions, onLine func(line string)) (string, error) {
+	buildArgs, err := opts.buildArgs()
+	if err != nil {
+		return "", err
+	}
+	opts, cleanup, err := prepareBuildContext(opts)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
 	args := []string{"build"}
-	if imageName != "" {
-		args = append(args, "-t", imageName)
+	for _, tag := range opts.Tags {
+		args = append(args, "-t", tag)
+	}
+	for _, key := range slices.Sorted(maps.Keys(buildArgs)) {
+		args = append(args, "--build-arg", key+"="+buildArgs[key])
+	}
+	if opts.Target != "" {
+		args = append(args, "--target", opts.Target)
+	}
+	for _, label := range opts.Labels {
+		args = append(args, "--label", label)
+	}
+	if opts.NoCache {
+		args = append(args, "--no-cache")
+	}
+	if opts.Pull {
+		args = append(args, "--pull=always")
+	}
+	args = append(args, "-f", opts.ContainerFile, opts.contextDir())
+	cmd := exec.CommandContext(ctx, p.filePath, args...)
+	// The same writer for stdout and stderr keeps the step lines and the final image ID in order.
+	output := newBuildLog(onLine)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	err = cmd.Run()
+	output.flush()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", output.tail(), err)
+	}
+	return output.result(output.lastLine()), nil
+}
+
+// ImageHistory
+// https://docs.podman.io/en/stable/markdown/podman-history.1.html
+func (p *podmanCli) ImageHistory(imageName string) (string, error) {
+	stdout, stderr, err := p.execSeparate("history", "--format", "json", imageName)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	layers, err := parseImageHistory(stdout)
+	if err != nil {
+		return "", err
 	}
-	return p.exec(append(args, "-f", containerFile)...)
+	return formatImageHistory(layers, p.outputFormat)
+}
+
+// ImageInspect
+// https://docs.podman.io/en/stable/markdown/podman-image-inspect.1.html
+func (p *podmanCli) ImageInspect(imageName string) (string, error) {
+	return p.exec("image", "inspect", imageName)
 }
 
 // ImageList
@@ -153,16 +844,48 @@ func (p *podmanCli) ImageList() (string, error) {
 	return p.exec(args...)
 }
 
+// ImageLoad
+// https://docs.podman.io/en/stable/markdown/podman-load.1.html
+func (p *podmanCli) ImageLoad(path string) (string, error) {
+	return p.exec("load", "--input", path)
+}
+
+// ImagePrune
+// https://docs.podman.io/en/stable/markdown/podman-image-prune.1.html
+func (p *podmanCli) ImagePrune(all bool, filters map[string][]string, dryRun bool) (string, error) {
+	listArgs := slices.Concat([]string{"images", "-a", "--format", "json"}, filterFlags(imagePruneFilters(all, filters)))
+	stdout, stderr, err := p.execSeparate(listArgs...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	candidates, err := parseImagePruneCandidates(stdout)
+	if err != nil {
+		return "", err
+	}
+	if dryRun {
+		return formatPruneReport("image", candidates, true, p.outputFormat)
+	}
+	args := []string{"image", "prune", "--force"}
+	if all {
+		args = append(args, "--all")
+	}
+	stdout, stderr, err = p.execSeparate(append(args, filterFlags(filters)...)...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	return formatPruneReport("image", prunedResources(candidates, parsePruneOutput(stdout)), false, p.outputFormat)
+}
+
 // ImagePull
 // https://docs.podman.io/en/stable/markdown/podman-pull.1.html
 func (p *podmanCli) ImagePull(imageName string) (string, error) {
-	output, err := p.exec("image", "pull", imageName)
+	output, err := p.exec(slices.Concat([]string{"image", "pull"}, p.authFileFlags(), []string{imageName})...)
 	if err == nil {
 		return fmt.Sprintf("%s\n%s pulled successfully", output, imageName), nil
 	}
 	if strings.Contains(output, "Error: short-name") {
 		imageName = "docker.io/" + imageName
-		if output, err = p.exec("pull", imageName); err == nil {
+		if output, err = p.exec(slices.Concat([]string{"pull"}, p.authFileFlags(), []string{imageName})...); err == nil {
 			return fmt.Sprintf("%s\n%s pulled successfully", output, imageName), nil
 		}
 	}
@@ -172,7 +895,7 @@ func (p *podmanCli) ImagePull(imageName string) (string, error) {
 // ImagePush
 // https://docs.podman.io/en/stable/markdown/podman-push.1.html
 func (p *podmanCli) ImagePush(imageName string) (string, error) {
-	output, err := p.exec("image", "push", imageName)
+	output, err := p.exec(slices.Concat([]string{"image", "push"}, p.authFileFlags(), []string{imageName})...)
 	if err == nil {
 		return fmt.Sprintf("%s\n%s pushed successfully", output, imageName), nil
 	}
@@ -185,6 +908,48 @@ func (p *podmanCli) ImageRemove(imageName string) (string, error) {
 	return p.exec("image", "rm", imageName)
 }
 
+// ImageSave
+// https://docs.podman.io/en/stable/markdown/podman-save.1.html
+func (p *podmanCli) ImageSave(images []string, path string, format string) error {
+	format, err := imageSaveFormat(images, format)
+	if err != nil {
+		return err
+	}
+	args := []string{"save", "--format", format, "--output", path}
+	if len(images) > 1 {
+		args = append(args, "--multi-image-archive")
+	}
+	if out, err := p.exec(append(args, images...)...); err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(out), err)
+	}
+	return nil
+}
+
+// ImageTag
+// https://docs.podman.io/en/stable/markdown/podman-tag.1.html
+func (p *podmanCli) ImageTag(source string, target string) (string, error) {
+	if output, err := p.exec("image", "tag", source, target); err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return fmt.Sprintf("%s tagged as %s", source, target), nil
+}
+
+// ImageUntag
+// https://docs.podman.io/en/stable/markdown/podman-untag.1.html
+func (p *podmanCli) ImageUntag(imageName string, tag string) (string, error) {
+	args := []string{"image", "untag", imageName}
+	if tag != "" {
+		args = append(args, tag)
+	}
+	if output, err := p.exec(args...); err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	if tag == "" {
+		return fmt.Sprintf("All tags removed from %s", imageName), nil
+	}
+	return fmt.Sprintf("%s removed from %s", tag, imageName), nil
+}
+
 // NetworkList
 // https://docs.podman.io/en/stable/markdown/podman-network-ls.1.html
 func (p *podmanCli) NetworkList() (string, error) {
@@ -195,6 +960,48 @@ func (p *podmanCli) NetworkList() (string, error) {
 	return p.exec(args...)
 }
 
+// RegistryListLogins lists the registries with credentials stored in the auth file, podman has no command for it.
+func (p *podmanCli) RegistryListLogins() (string, error) {
+	logins, err := listRegistryLogins(p.authFile)
+	if err != nil {
+		return "", err
+	}
+	return formatRegistryLogins(logins, p.outputFormat)
+}
+
+// RegistryLogin
+// https://docs.podman.io/en/stable/markdown/podman-login.1.html
+func (p *podmanCli) RegistryLogin(registry string, opts RegistryLoginOptions) (string, error) {
+	args := slices.Concat([]string{"login"}, p.authFileFlags(), []string{"--username", opts.Username, "--password-stdin"})
+	if opts.SkipTLSVerify {
+		args = append(args, "--tls-verify=false")
+	}
+	// The password is read from stdin so that it isn't exposed in the process list
+	cmd := exec.Command(p.filePath, append(args, registry)...)
+	cmd.Stdin = strings.NewReader(opts.Password)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// RegistryLogout
+// https://docs.podman.io/en/stable/markdown/podman-logout.1.html
+func (p *podmanCli) RegistryLogout(registry string, all bool) (string, error) {
+	args := slices.Concat([]string{"logout"}, p.authFileFlags())
+	if all {
+		args = append(args, "--all")
+	} else {
+		args = append(args, registry)
+	}
+	output, err := p.exec(args...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
 // VolumeList
 // https://docs.podman.io/en/stable/markdown/podman-volume-ls.1.html
 func (p *podmanCli) VolumeList() (string, error) {
@@ -205,7 +1012,91 @@ func (p *podmanCli) VolumeList() (string, error) {
 	return p.exec(args...)
 }
 
+// filterFlags returns the podman --filter flags for the filters (key to values) sorted by key.
+func filterFlags(filters map[string][]string) []string {
+	var flags []string
+	for _, key := range slices.Sorted(maps.Keys(filters)) {
+		for _, value := range filters[key] {
+			flags = append(flags, "--filter", key+"="+value)
+		}
+	}
+	return flags
+}
+
+// parsePruneOutput parses the IDs of the resources removed by podman container prune or podman image prune, printed one per line.
+func parsePruneOutput(output string) []pruneResource {
+	var pruned []pruneResource
+	for _, id := range strings.Fields(output) {
+		pruned = append(pruned, pruneResource{ID: id})
+	}
+	return pruned
+}
+
+// logsFlags returns the podman logs flags for the provided options.
+func logsFlags(opts LogOptions) []string {
+	var flags []string
+	if opts.Tail > 0 {
+		flags = append(flags, "--tail", strconv.Itoa(opts.Tail))
+	}
+	if opts.Since != "" {
+		flags = append(flags, "--since", opts.Since)
+	}
+	if opts.Until != "" {
+		flags = append(flags, "--until", opts.Until)
+	}
+	if opts.Timestamps {
+		flags = append(flags, "--timestamps")
+	}
+	return flags
+}
+
+// authFileFlags returns the flags to use the configured registry auth file, none for the podman default.
+func (p *podmanCli) authFileFlags() []string {
+	if p.authFile == "" {
+		return nil
+	}
+	return []string{"--authfile", p.authFile}
+}
+
 func (p *podmanCli) exec(args ...string) (string, error) {
 	output, err := exec.Command(p.filePath, args...).CombinedOutput()
 	return string(output), err
 }
+
+// execStream runs podman with the given arguments passing its stdout as a stream to read.
+// podman is killed if read fails so that it doesn't produce the rest of the output, otherwise the unread output is discarded.
+func (p *podmanCli) execStream(read func(stdout io.Reader) error, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(p.filePath, args...)
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	readErr := read(stdout)
+	if readErr != nil {
+		_ = cmd.Process.Kill()
+	} else {
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+	if err = cmd.Wait(); readErr != nil {
+		return readErr
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+	return nil
+}
+
+// execSeparate runs podman with the given arguments and returns stdout and stderr separately.
+func (p *podmanCli) execSeparate(args ...string) (string, string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(p.filePath, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
@@ -1,10 +1,12 @@
 package podman_test
 
 import (
+	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -162,6 +164,126 @@ func (s *PodmanCliSuite) TestCLINew() {
 	})
 }
 
+func (s *PodmanCliSuite) TestCLIContainerTop() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("mock podman script is a shell script")
+	}
+	tmpDir := s.createMockBinariesInPath()
+	// ps -ef output, the numeric columns are right-aligned and the command contains spaces
+	script := `#!/bin/sh
+PATH=/usr/bin:/bin
+if [ "$1" = "top" ]; then
+cat <<'EOF'
+UID          PID    PPID  C STIME TTY          TIME CMD
+root           1       0  0 10:00 ?        00:00:00 nginx: master process nginx -g daemon off;
+nginx         29       1  0 10:00 ?        00:00:00 nginx: worker process
+nginx      12345       1 12 10:01 pts/0    00:01:02 nginx: worker process
+EOF
+fi
+exit 0
+`
+	s.Require().NoError(os.WriteFile(filepath.Join(tmpDir, "podman"), []byte(script), 0755))
+	impl := podman.ImplementationFromString("cli")
+	s.Require().NotNil(impl)
+
+	s.Run("ps -ef output is split into the header columns", func() {
+		p, err := impl.Initialize(config.Config{OutputFormat: config.OutputFormatJSON})
+		s.Require().NoError(err)
+
+		result, err := p.ContainerTop("web", []string{"-ef"})
+		s.Require().NoError(err)
+
+		var processes []map[string]string
+		s.Require().NoError(json.Unmarshal([]byte(result), &processes))
+		s.Equal([]map[string]string{
+			{"UID": "root", "PID": "1", "PPID": "0", "C": "0", "STIME": "10:00", "TTY": "?", "TIME": "00:00:00",
+				"CMD": "nginx: master process nginx -g daemon off;"},
+			{"UID": "nginx", "PID": "29", "PPID": "1", "C": "0", "STIME": "10:00", "TTY": "?", "TIME": "00:00:00",
+				"CMD": "nginx: worker process"},
+			{"UID": "nginx", "PID": "12345", "PPID": "1", "C": "12", "STIME": "10:01", "TTY": "pts/0", "TIME": "00:01:02",
+				"CMD": "nginx: worker process"},
+		}, processes)
+	})
+}
+
+func (s *PodmanCliSuite) TestCLIContainerLogsFollow() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("mock podman script is a shell script")
+	}
+	tmpDir := s.createMockBinariesInPath()
+	// Prints a line of $LINE_SIZE characters between two short lines
+	script := `#!/bin/sh
+PATH=/usr/bin:/bin
+if [ "$1" = "logs" ]; then
+echo first
+head -c "$LINE_SIZE" /dev/zero | tr '\0' a
+echo
+echo last
+fi
+exit 0
+`
+	s.Require().NoError(os.WriteFile(filepath.Join(tmpDir, "podman"), []byte(script), 0755))
+	impl := podman.ImplementationFromString("cli")
+	s.Require().NotNil(impl)
+	p, err := impl.Initialize(config.Config{})
+	s.Require().NoError(err)
+	follow := func() ([]string, error) {
+		var lines []string
+		err := p.ContainerLogsFollow(s.T().Context(), "web", podman.LogOptions{Stdout: true}, func(line string) bool {
+			lines = append(lines, line)
+			return true
+		})
+		return lines, err
+	}
+
+	s.Run("lines longer than 64KiB are followed", func() {
+		s.T().Setenv("LINE_SIZE", "100000")
+		lines, err := follow()
+		s.Require().NoError(err)
+		s.Require().Len(lines, 3)
+		s.Equal("first\n", lines[0])
+		s.Equal(strings.Repeat("a", 100000)+"\n", lines[1])
+		s.Equal("last\n", lines[2])
+	})
+
+	s.Run("lines exceeding the maximum size return error", func() {
+		s.T().Setenv("LINE_SIZE", "2000000")
+		lines, err := follow()
+		s.ErrorContains(err, "failed to read the container logs: bufio.Scanner: token too long")
+		s.Equal([]string{"first\n"}, lines)
+	})
+}
+
+func (s *PodmanCliSuite) TestCLIImageBuild() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("mock podman script is a shell script")
+	}
+	tmpDir := s.createMockBinariesInPath()
+	// Prints the build steps followed by the image ID, like podman build
+	script := `#!/bin/sh
+if [ "$1" = "build" ]; then
+echo "STEP 1/1: FROM alpine"
+echo "COMMIT"
+echo "a1b2c3d4e5f6"
+fi
+exit 0
+`
+	s.Require().NoError(os.WriteFile(filepath.Join(tmpDir, "podman"), []byte(script), 0755))
+	impl := podman.ImplementationFromString("cli")
+	s.Require().NotNil(impl)
+	p, err := impl.Initialize(config.Config{})
+	s.Require().NoError(err)
+
+	result, err := p.ImageBuild(s.T().Context(), podman.ImageBuildOptions{ContainerFile: "/tmp/Containerfile"}, nil)
+
+	s.Run("returns OK", func() {
+		s.NoError(err)
+	})
+	s.Run("returns the build output and the image ID once", func() {
+		s.Equal("STEP 1/1: FROM alpine\nCOMMIT\nImage ID: a1b2c3d4e5f6", result)
+	})
+}
+
 func (s *PodmanCliSuite) TestNewPodmanWithCLI() {
 	s.Run("empty override returns CLI implementation", func() {
 		s.createMockBinariesInPath("podman")
@@ -0,0 +1,90 @@
+package podman
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// portBinding is a container port published on a host port.
+type portBinding struct {
+	HostIP        string `json:"hostIp"`
+	HostPort      int    `json:"hostPort"`
+	ContainerPort int    `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+}
+
+// newPortBinding returns the binding of the container port (in <port>/<protocol> format) to the host address.
+// An empty host IP means the port is published on all the host interfaces.
+func newPortBinding(containerPort string, hostIP string, hostPort string) (portBinding, error) {
+	port, protocol, _ := strings.Cut(containerPort, "/")
+	binding := portBinding{HostIP: hostIP, Protocol: cmp.Or(protocol, "tcp")}
+	var err error
+	if binding.ContainerPort, err = strconv.Atoi(port); err != nil {
+		return portBinding{}, fmt.Errorf("invalid container port %s: %w", containerPort, err)
+	}
+	if binding.HostPort, err = strconv.Atoi(hostPort); err != nil {
+		return portBinding{}, fmt.Errorf("invalid host port %s: %w", hostPort, err)
+	}
+	if binding.HostIP == "" {
+		binding.HostIP = "0.0.0.0"
+	}
+	return binding, nil
+}
+
+// parsePortBindings parses the "<port>/<protocol> -> <hostIp>:<hostPort>" lines printed by podman port.
+func parsePortBindings(output string) ([]portBinding, error) {
+	var bindings []portBinding
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		containerPort, hostAddress, ok := strings.Cut(line, " -> ")
+		if !ok {
+			return nil, fmt.Errorf("unexpected podman port output: %s", line)
+		}
+		hostIP, hostPort, err := net.SplitHostPort(strings.TrimSpace(hostAddress))
+		if err != nil {
+			return nil, err
+		}
+		binding, err := newPortBinding(strings.TrimSpace(containerPort), hostIP, hostPort)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
+
+// formatPortBindings formats the port bindings sorted by container port as a table, or as JSON.
+func formatPortBindings(bindings []portBinding, outputFormat string) (string, error) {
+	slices.SortFunc(bindings, func(a, b portBinding) int {
+		return cmp.Or(
+			cmp.Compare(a.ContainerPort, b.ContainerPort),
+			strings.Compare(a.Protocol, b.Protocol),
+			strings.Compare(a.HostIP, b.HostIP),
+			cmp.Compare(a.HostPort, b.HostPort),
+		)
+	})
+	if outputFormat == config.OutputFormatJSON {
+		if bindings == nil {
+			bindings = []portBinding{}
+		}
+		return toJSON(bindings)
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "HOST IP\tHOST PORT\tCONTAINER PORT\tPROTOCOL")
+	for _, b := range bindings {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", b.HostIP, b.HostPort, b.ContainerPort, b.Protocol)
+	}
+	_ = w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
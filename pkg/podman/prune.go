@@ -0,0 +1,165 @@
+package podman
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// pruneResource is a container or image removed (or that would be removed) by a prune.
+type pruneResource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// pruneReport is the outcome of a prune, or of its preview if DryRun is set.
+type pruneReport struct {
+	DryRun    bool            `json:"dryRun"`
+	Resources []pruneResource `json:"resources"`
+	TotalSize int64           `json:"totalSize"`
+}
+
+// containerPruneFilters returns the container list filters that select the containers removed by a container prune:
+// the stopped containers matching the prune filters.
+func containerPruneFilters(filters map[string][]string) map[string][]string {
+	listFilters := maps.Clone(filters)
+	if listFilters == nil {
+		listFilters = map[string][]string{}
+	}
+	listFilters["status"] = []string{"created", "exited"}
+	return listFilters
+}
+
+// imagePruneFilters returns the image list filters that select the images removed by an image prune:
+// the images not used by any container matching the prune filters, restricted to dangling images unless all is set.
+func imagePruneFilters(all bool, filters map[string][]string) map[string][]string {
+	listFilters := maps.Clone(filters)
+	if listFilters == nil {
+		listFilters = map[string][]string{}
+	}
+	listFilters["containers"] = []string{"false"}
+	if !all {
+		listFilters["dangling"] = []string{"true"}
+	}
+	return listFilters
+}
+
+// imagePruneID returns the image ID without its digest algorithm prefix, as it's displayed by podman.
+func imagePruneID(id string) string {
+	return strings.TrimPrefix(id, "sha256:")
+}
+
+// imagePruneName returns the name of an image listed as a prune candidate, <none> if it is untagged.
+func imagePruneName(repoTags []string) string {
+	for _, tag := range repoTags {
+		if tag != "<none>:<none>" {
+			return tag
+		}
+	}
+	return "<none>"
+}
+
+// parseContainerPruneCandidates parses the JSON output of podman container list --size into prune candidates.
+func parseContainerPruneCandidates(output string) ([]pruneResource, error) {
+	var list []struct {
+		ID    string   `json:"Id"`
+		Names []string `json:"Names"`
+		Size  *struct {
+			RwSize int64 `json:"rwSize"`
+		} `json:"Size"`
+	}
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse container list: %w", err)
+	}
+	candidates := make([]pruneResource, 0, len(list))
+	for _, c := range list {
+		candidate := pruneResource{ID: c.ID, Name: strings.Join(c.Names, ",")}
+		if c.Size != nil {
+			candidate.Size = c.Size.RwSize
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+// parseImagePruneCandidates parses the JSON output of podman images into prune candidates.
+func parseImagePruneCandidates(output string) ([]pruneResource, error) {
+	var list []struct {
+		ID       string   `json:"Id"`
+		RepoTags []string `json:"RepoTags"`
+		Size     int64    `json:"Size"`
+	}
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse image list: %w", err)
+	}
+	candidates := make([]pruneResource, 0, len(list))
+	for _, i := range list {
+		candidates = append(candidates, pruneResource{ID: imagePruneID(i.ID), Name: imagePruneName(i.RepoTags), Size: i.Size})
+	}
+	return candidates, nil
+}
+
+// prunedResources completes the resources removed by a prune with the names (and sizes, if unknown) of the
+// candidates listed beforehand. Removed resources that weren't listed as candidates are reported as they are.
+func prunedResources(candidates []pruneResource, pruned []pruneResource) []pruneResource {
+	resources := make([]pruneResource, 0, len(pruned))
+	for _, r := range pruned {
+		r.ID = imagePruneID(r.ID)
+		for _, c := range candidates {
+			if strings.HasPrefix(c.ID, r.ID) || strings.HasPrefix(r.ID, c.ID) {
+				r.ID = c.ID
+				r.Name = c.Name
+				r.Size = cmp.Or(r.Size, c.Size)
+				break
+			}
+		}
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+// formatPruneReport formats the kind (container or image) resources removed by a prune,
+// or that would be removed if dryRun is set, with their total size as a table, or as JSON.
+func formatPruneReport(kind string, resources []pruneResource, dryRun bool, outputFormat string) (string, error) {
+	report := pruneReport{DryRun: dryRun, Resources: resources}
+	if report.Resources == nil {
+		report.Resources = []pruneResource{}
+	}
+	for _, r := range resources {
+		report.TotalSize += r.Size
+	}
+	if outputFormat == config.OutputFormatJSON {
+		return toJSON(report)
+	}
+	if len(resources) == 0 {
+		if dryRun {
+			return fmt.Sprintf("No %ss would be removed", kind), nil
+		}
+		return fmt.Sprintf("No %ss were removed", kind), nil
+	}
+	var buf bytes.Buffer
+	if dryRun {
+		_, _ = fmt.Fprintf(&buf, "The following %ss would be removed:\n", kind)
+	} else {
+		_, _ = fmt.Fprintf(&buf, "Removed %ss:\n", kind)
+	}
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "%s ID\tNAME\tSIZE\n", strings.ToUpper(kind))
+	for _, r := range resources {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", shortID(r.ID), r.Name, formatSize(r.Size))
+	}
+	_ = w.Flush()
+	if dryRun {
+		_, _ = fmt.Fprintf(&buf, "Total reclaimable space: %s", formatSize(report.TotalSize))
+	} else {
+		_, _ = fmt.Fprintf(&buf, "Total reclaimed space: %s", formatSize(report.TotalSize))
+	}
+	return buf.String(), nil
+}
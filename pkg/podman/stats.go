@@ -0,0 +1,195 @@
+package podman
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// statsSnapshotFunc takes a single resource usage statistics sample of the specified containers.
+type statsSnapshotFunc func(names []string) ([]ContainerStats, error)
+
+// statsRange holds the minimum, average and maximum values of a sampled metric.
+type statsRange struct {
+	Min float64 `json:"min"`
+	Avg float64 `json:"avg"`
+	Max float64 `json:"max"`
+}
+
+// containerStatsSummary holds the resource usage statistics of a container aggregated over a window.
+type containerStatsSummary struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Samples     int        `json:"samples"`
+	CPUPercent  statsRange `json:"cpuPercent"`
+	MemUsage    statsRange `json:"memUsage"`
+	MemPercent  statsRange `json:"memPercent"`
+	NetInput    statsRange `json:"netInput"`
+	NetOutput   statsRange `json:"netOutput"`
+	BlockInput  statsRange `json:"blockInput"`
+	BlockOutput statsRange `json:"blockOutput"`
+	PIDs        statsRange `json:"pids"`
+}
+
+// collectStats returns a single formatted snapshot of the container statistics,
+// or the statistics sampled and aggregated over opts.Window, stopping the sampling if ctx is done.
+func collectStats(ctx context.Context, names []string, opts StatsOptions, outputFormat string, snapshot statsSnapshotFunc) (string, error) {
+	if opts.Window <= 0 {
+		stats, err := snapshot(names)
+		if err != nil {
+			return "", err
+		}
+		if outputFormat == config.OutputFormatJSON {
+			return toJSON(stats)
+		}
+		return formatStats(stats), nil
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	count := int(opts.Window/interval) + 1
+	samples := make([][]ContainerStats, 0, count)
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+		stats, err := snapshot(names)
+		if err != nil {
+			return "", err
+		}
+		samples = append(samples, stats)
+	}
+	summaries := summarizeStats(samples)
+	if outputFormat == config.OutputFormatJSON {
+		return toJSON(summaries)
+	}
+	return formatStatsSummaries(summaries), nil
+}
+
+// summarizeStats aggregates the samples per container, in order of first appearance.
+// The CPU percentage is computed from the CPU time consumed between consecutive samples
+// (as podman does for streamed stats), the reported value is used if there's a single sample.
+func summarizeStats(samples [][]ContainerStats) []containerStatsSummary {
+	var ids []string
+	perContainer := make(map[string][]ContainerStats)
+	for _, sample := range samples {
+		for _, s := range sample {
+			if _, ok := perContainer[s.ID]; !ok {
+				ids = append(ids, s.ID)
+			}
+			perContainer[s.ID] = append(perContainer[s.ID], s)
+		}
+	}
+	summaries := make([]containerStatsSummary, 0, len(ids))
+	for _, id := range ids {
+		stats := perContainer[id]
+		cpu := make([]float64, 0, len(stats))
+		for i := 1; i < len(stats); i++ {
+			prev, cur := stats[i-1], stats[i]
+			// Skip samples where the counters went backwards (e.g. the container was restarted)
+			if cur.SystemNano > prev.SystemNano && cur.CPUNano >= prev.CPUNano {
+				cpu = append(cpu, float64(cur.CPUNano-prev.CPUNano)/float64(cur.SystemNano-prev.SystemNano)*100)
+			}
+		}
+		if len(cpu) == 0 {
+			cpu = append(cpu, stats[0].CPUPercent)
+		}
+		summaries = append(summaries, containerStatsSummary{
+			ID:          id,
+			Name:        stats[len(stats)-1].Name,
+			Samples:     len(stats),
+			CPUPercent:  newStatsRange(cpu),
+			MemUsage:    statsRangeOf(stats, func(s ContainerStats) float64 { return float64(s.MemUsage) }),
+			MemPercent:  statsRangeOf(stats, func(s ContainerStats) float64 { return s.MemPercent }),
+			NetInput:    statsRangeOf(stats, func(s ContainerStats) float64 { return float64(s.NetInput) }),
+			NetOutput:   statsRangeOf(stats, func(s ContainerStats) float64 { return float64(s.NetOutput) }),
+			BlockInput:  statsRangeOf(stats, func(s ContainerStats) float64 { return float64(s.BlockInput) }),
+			BlockOutput: statsRangeOf(stats, func(s ContainerStats) float64 { return float64(s.BlockOutput) }),
+			PIDs:        statsRangeOf(stats, func(s ContainerStats) float64 { return float64(s.PIDs) }),
+		})
+	}
+	return summaries
+}
+
+// statsRangeOf computes the range of the metric extracted from each sample.
+func statsRangeOf(stats []ContainerStats, metric func(ContainerStats) float64) statsRange {
+	values := make([]float64, 0, len(stats))
+	for _, s := range stats {
+		values = append(values, metric(s))
+	}
+	return newStatsRange(values)
+}
+
+// newStatsRange computes the minimum, average and maximum of the (non-empty) values.
+func newStatsRange(values []float64) statsRange {
+	r := statsRange{Min: values[0], Max: values[0]}
+	sum := 0.0
+	for _, v := range values {
+		r.Min = min(r.Min, v)
+		r.Max = max(r.Max, v)
+		sum += v
+	}
+	r.Avg = sum / float64(len(values))
+	return r
+}
+
+// formatStats formats a statistics snapshot as a table similar to podman stats.
+func formatStats(stats []ContainerStats) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tNAME\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET IO\tBLOCK IO\tPIDS")
+	for _, s := range stats {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%.2f%%\t%s / %s\t%.2f%%\t%s / %s\t%s / %s\t%d\n",
+			shortID(s.ID), s.Name, s.CPUPercent,
+			units.HumanSize(float64(s.MemUsage)), units.HumanSize(float64(s.MemLimit)), s.MemPercent,
+			units.HumanSize(float64(s.NetInput)), units.HumanSize(float64(s.NetOutput)),
+			units.HumanSize(float64(s.BlockInput)), units.HumanSize(float64(s.BlockOutput)),
+			s.PIDs)
+	}
+	_ = w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// formatStatsSummaries formats the aggregated statistics as a table with a row per container and metric.
+func formatStatsSummaries(summaries []containerStatsSummary) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tNAME\tSAMPLES\tMETRIC\tMIN\tAVG\tMAX")
+	percent := func(v float64) string { return fmt.Sprintf("%.2f%%", v) }
+	size := func(v float64) string { return units.HumanSize(v) }
+	count := func(v float64) string { return fmt.Sprintf("%.0f", v) }
+	for _, s := range summaries {
+		metrics := []struct {
+			name   string
+			values statsRange
+			format func(float64) string
+		}{
+			{"CPU %", s.CPUPercent, percent},
+			{"MEM USAGE", s.MemUsage, size},
+			{"MEM %", s.MemPercent, percent},
+			{"NET INPUT", s.NetInput, size},
+			{"NET OUTPUT", s.NetOutput, size},
+			{"BLOCK INPUT", s.BlockInput, size},
+			{"BLOCK OUTPUT", s.BlockOutput, size},
+			{"PIDS", s.PIDs, count},
+		}
+		for _, m := range metrics {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+				shortID(s.ID), s.Name, s.Samples, m.name, m.format(m.values.Min), m.format(m.values.Avg), m.format(m.values.Max))
+		}
+	}
+	_ = w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,71 @@
+package podman
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/manusa/podman-mcp-server/pkg/config"
+)
+
+// formatTop formats the processes of a container (titles followed by one row per process)
+// as a table, or as a JSON array with an object per process keyed by title.
+func formatTop(titles []string, processes [][]string, outputFormat string) (string, error) {
+	if outputFormat == config.OutputFormatJSON {
+		result := make([]map[string]string, 0, len(processes))
+		for _, process := range processes {
+			entry := make(map[string]string, len(titles))
+			for i, title := range titles {
+				if i < len(process) {
+					entry[title] = process[i]
+				}
+			}
+			result = append(result, entry)
+		}
+		return toJSON(result)
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, strings.Join(titles, "\t"))
+	for _, process := range processes {
+		_, _ = fmt.Fprintln(w, strings.Join(process, "\t"))
+	}
+	_ = w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// parseTopTable parses the table printed by podman top.
+// The values are split at whitespace since ps(1) right-aligns its numeric columns (e.g. ps -ef), so the values aren't
+// aligned with the header titles. The last column takes the rest of the line since it may contain spaces (e.g. COMMAND).
+func parseTopTable(output string) ([]string, [][]string) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) == "" {
+		return nil, nil
+	}
+	titles := strings.Fields(lines[0])
+	processes := make([][]string, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		processes = append(processes, splitColumns(line, len(titles)))
+	}
+	return titles, processes
+}
+
+// splitColumns splits the line into at most n whitespace separated values, the last value takes the rest of the line.
+func splitColumns(line string, n int) []string {
+	values := make([]string, 0, n)
+	rest := strings.TrimSpace(line)
+	for rest != "" {
+		i := strings.IndexFunc(rest, unicode.IsSpace)
+		if i < 0 || len(values) == n-1 {
+			return append(values, rest)
+		}
+		values = append(values, rest[:i])
+		rest = strings.TrimLeftFunc(rest[i:], unicode.IsSpace)
+	}
+	return values
+}
@@ -0,0 +1,279 @@
+package podman
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ExecResult holds the outcome of a command executed inside a container.
+type ExecResult struct {
+	// Stdout is the standard output produced by the command.
+	Stdout string
+	// Stderr is the standard error produced by the command.
+	Stderr string
+	// ExitCode is the exit code returned by the command.
+	ExitCode int
+}
+
+// WaitResult holds the outcome of waiting for a container condition.
+type WaitResult struct {
+	// ExitCode is the exit code of the container, -1 if the condition met isn't an exit condition (e.g. running).
+	ExitCode int
+	// State is the state of the container once the condition is met, empty if it no longer exists (e.g. auto-removed).
+	State string
+}
+
+// PortMapping is a container port published on a host address.
+type PortMapping struct {
+	// HostIP is the host IP the port is published on, all the host interfaces if empty.
+	HostIP        string
+	HostPort      int
+	ContainerPort int
+	// Protocol is the port protocol: tcp, udp or sctp.
+	Protocol string
+}
+
+// ContainerRunOptions holds the options to create and run a container.
+type ContainerRunOptions struct {
+	// Image is the name of the image to run (required).
+	Image string
+	// Name is the name to assign to the container.
+	Name string
+	// Ports maps host ports to container ports.
+	// If empty, and there are no PortMappings, all the ports exposed by the image are published to random host ports.
+	Ports map[int]int
+	// PortMappings contains the port mappings with a host IP and protocol, published in addition to Ports.
+	PortMappings []PortMapping
+	// Env contains environment variables in <key>=<value> format.
+	Env []string
+	// Volumes contains bind mounts or named volumes in [<host-dir|volume-name>:]<container-dir>[:<options>] format.
+	Volumes []string
+	// Network is the network (or network mode such as host or none) to connect the container to.
+	Network string
+	// Command overrides the default command of the image.
+	Command []string
+	// Entrypoint overrides the default entrypoint of the image.
+	Entrypoint []string
+	// WorkDir overrides the working directory inside the container.
+	WorkDir string
+	// User overrides the user (and optionally the group) the container process runs as.
+	User string
+	// Labels contains metadata labels in <key>=<value> format.
+	Labels []string
+	// Memory is the memory limit with an optional unit suffix (b, k, m, g). Example: 512m.
+	Memory string
+	// CPUs is the number of CPUs the container can use. Example: 1.5.
+	CPUs float64
+	// RestartPolicy is the restart policy: no, always, on-failure[:max-retries] or unless-stopped.
+	RestartPolicy string
+	// AutoRemove removes the container when it exits (--rm).
+	AutoRemove bool
+}
+
+// ContainerListOptions holds the options to select the containers returned by ContainerList.
+// Containers match a filter if they match any of its values, except for Label where they must match all of them,
+// and must match all the specified filters.
+type ContainerListOptions struct {
+	// All includes the containers that are not running.
+	All bool
+	// Status filters by container state: created, running, paused, exited, stopping, removing or unknown.
+	Status []string
+	// Label filters by label key or <key>=<value>, containers must have all the labels.
+	Label []string
+	// Name filters by container name (regular expression).
+	Name []string
+	// Ancestor filters by the image (name, ID or name:tag) the containers were created from.
+	Ancestor []string
+	// Network filters by the name or ID of a network the containers are connected to.
+	Network []string
+	// Since filters the containers created after the given container ID or name.
+	Since string
+	// Before filters the containers created before the given container ID or name.
+	Before string
+}
+
+// filters returns the podman filters (key to values) of the options.
+func (o ContainerListOptions) filters() map[string][]string {
+	filters := make(map[string][]string)
+	for key, values := range map[string][]string{
+		"status":   o.Status,
+		"label":    o.Label,
+		"name":     o.Name,
+		"ancestor": o.Ancestor,
+		"network":  o.Network,
+	} {
+		if len(values) > 0 {
+			filters[key] = values
+		}
+	}
+	if o.Since != "" {
+		filters["since"] = []string{o.Since}
+	}
+	if o.Before != "" {
+		filters["before"] = []string{o.Before}
+	}
+	return filters
+}
+
+// ContainerCloneOptions holds the settings to change when cloning a container.
+// Zero values keep the settings of the cloned container.
+type ContainerCloneOptions struct {
+	// Name is the name of the new container, defaults to the name of the cloned container with a -clone suffix.
+	Name string
+	// Image replaces the image of the cloned container.
+	Image string
+	// Env contains environment variables in <key>=<value> format to add or override.
+	Env []string
+	// Memory is the memory limit with an optional unit suffix (b, k, m, g). Example: 512m.
+	Memory string
+	// CPUs is the number of CPUs the container can use. Example: 1.5.
+	CPUs float64
+	// Start starts the new container after creating it.
+	Start bool
+}
+
+// ContainerUpdateOptions holds the resource limits and restart policy to change on an existing container.
+// Zero values are left unchanged.
+type ContainerUpdateOptions struct {
+	// Memory is the memory limit with an optional unit suffix (b, k, m, g). Example: 512m.
+	Memory string
+	// MemorySwap is the memory plus swap limit with an optional unit suffix, -1 for unlimited swap.
+	MemorySwap string
+	// CPUQuota is the CPU time in microseconds the container can use in each CPUPeriod.
+	CPUQuota int64
+	// CPUPeriod is the CPU CFS scheduler period in microseconds.
+	CPUPeriod uint64
+	// CPUShares is the relative CPU weight of the container compared to other containers.
+	CPUShares uint64
+	// CPUSetCPUs are the CPUs the container is allowed to run on. Example: 0-2,4.
+	CPUSetCPUs string
+	// PidsLimit is the maximum number of processes of the container, -1 for unlimited.
+	PidsLimit int64
+	// RestartPolicy is the restart policy: no, always, on-failure[:max-retries] or unless-stopped.
+	RestartPolicy string
+}
+
+// ImageBuildOptions holds the options to build an image.
+type ImageBuildOptions struct {
+	// ContainerFile is the path to the Dockerfile, Podmanfile, or Containerfile to build.
+	// Required unless ContainerFileContent is provided, relative to the uploaded context if ContextFiles or ContextArchive
+	// are provided (defaults to Containerfile).
+	ContainerFile string
+	// ContainerFileContent is the content of the Containerfile to build, instead of a ContainerFile path.
+	ContainerFileContent string
+	// ContextDir is the build context directory, defaults to the directory of the ContainerFile.
+	ContextDir string
+	// ContextFiles maps the paths (relative to the build context) to the content of the files uploaded as build context,
+	// instead of a ContextDir.
+	ContextFiles map[string]string
+	// ContextArchive is a tar archive, optionally gzip compressed, uploaded as build context, instead of a ContextDir.
+	ContextArchive []byte
+	// Tags contains the names (and optional tags) assigned to the resulting image.
+	Tags []string
+	// BuildArgs contains build-time variables in <key>=<value> format.
+	BuildArgs []string
+	// Target is the stage to build in a multi-stage Containerfile, defaults to the last stage.
+	Target string
+	// Labels contains metadata labels in <key>=<value> format added to the image.
+	Labels []string
+	// NoCache builds all the layers without using the cached ones.
+	NoCache bool
+	// Pull always pulls the base images, even if they are present locally.
+	Pull bool
+}
+
+// contextDir returns the build context directory, the ContainerFile directory if not specified.
+func (o ImageBuildOptions) contextDir() string {
+	if o.ContextDir != "" {
+		return o.ContextDir
+	}
+	return filepath.Dir(o.ContainerFile)
+}
+
+// buildArgs returns the build-time variables (key to value).
+func (o ImageBuildOptions) buildArgs() (map[string]string, error) {
+	args := make(map[string]string, len(o.BuildArgs))
+	for _, arg := range o.BuildArgs {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid build arg %s, expected <key>=<value>", arg)
+		}
+		args[key] = value
+	}
+	return args, nil
+}
+
+// LogOptions holds the options to select the log entries returned by ContainerLogs.
+type LogOptions struct {
+	// Tail is the number of lines to show from the end of the logs.
+	// If zero or negative, all the lines are shown.
+	Tail int
+	// Since shows the logs since the given timestamp (RFC3339 or Unix) or relative duration (e.g. 10m).
+	Since string
+	// Until shows the logs until the given timestamp (RFC3339 or Unix) or relative duration (e.g. 10m).
+	Until string
+	// Timestamps prefixes each log line with its timestamp.
+	Timestamps bool
+	// Stdout includes the container's standard output stream.
+	Stdout bool
+	// Stderr includes the container's standard error stream.
+	Stderr bool
+}
+
+// RegistryLoginOptions holds the credentials and connection settings to log in to a registry.
+type RegistryLoginOptions struct {
+	// Username is the registry account name.
+	Username string
+	// Password is the registry account password or access token.
+	Password string
+	// SkipTLSVerify allows plain HTTP and self-signed certificates (e.g. local registries).
+	SkipTLSVerify bool
+}
+
+// StatsOptions holds the options to collect the resource usage statistics of containers.
+type StatsOptions struct {
+	// Window is the period over which the statistics are sampled and aggregated (min/avg/max).
+	// If zero, a single snapshot is returned.
+	Window time.Duration
+	// Interval is the time between samples when Window is set, defaults to 1 second.
+	Interval time.Duration
+}
+
+// ContainerStats holds a resource usage statistics sample of a container.
+type ContainerStats struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	CPUPercent float64 `json:"cpuPercent"`
+	// CPUNano is the total CPU time consumed by the container in nanoseconds.
+	CPUNano uint64 `json:"-"`
+	// SystemNano is the time when the sample was taken in nanoseconds since the Unix epoch.
+	SystemNano  uint64  `json:"-"`
+	MemUsage    uint64  `json:"memUsage"`
+	MemLimit    uint64  `json:"memLimit"`
+	MemPercent  float64 `json:"memPercent"`
+	NetInput    uint64  `json:"netInput"`
+	NetOutput   uint64  `json:"netOutput"`
+	BlockInput  uint64  `json:"blockInput"`
+	BlockOutput uint64  `json:"blockOutput"`
+	PIDs        uint64  `json:"pids"`
+}
+
+// ContainerHealth holds the healthcheck state of a container.
+type ContainerHealth struct {
+	// Status is starting, healthy or unhealthy.
+	Status string `json:"status"`
+	// FailingStreak is the number of consecutive failed probes.
+	FailingStreak int `json:"failingStreak"`
+	// Log contains the results of the latest probes, oldest first.
+	Log []HealthcheckLog `json:"log"`
+}
+
+// HealthcheckLog holds the result of a single healthcheck probe.
+type HealthcheckLog struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	ExitCode int    `json:"exitCode"`
+	Output   string `json:"output"`
+}
@@ -0,0 +1,24 @@
+package podman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// withWaitTimeout returns a context that is done when ctx is done or the timeout expires (no timeout if <= 0).
+func withWaitTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// waitError returns the error of a container wait interrupted because its context is done.
+func waitError(ctx context.Context, name string, timeout time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s waiting for container %s", timeout, name)
+	}
+	return fmt.Errorf("waiting for container %s was canceled: %w", name, ctx.Err())
+}